@@ -0,0 +1,360 @@
+package noaa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Values of the AlertsQuery filters and the matching Alert fields
+const (
+	AlertSeverityExtreme  = "Extreme"
+	AlertSeveritySevere   = "Severe"
+	AlertSeverityModerate = "Moderate"
+	AlertSeverityMinor    = "Minor"
+	AlertSeverityUnknown  = "Unknown"
+
+	AlertUrgencyImmediate = "Immediate"
+	AlertUrgencyExpected  = "Expected"
+	AlertUrgencyFuture    = "Future"
+	AlertUrgencyPast      = "Past"
+	AlertUrgencyUnknown   = "Unknown"
+
+	AlertCertaintyObserved = "Observed"
+	AlertCertaintyLikely   = "Likely"
+	AlertCertaintyPossible = "Possible"
+	AlertCertaintyUnlikely = "Unlikely"
+	AlertCertaintyUnknown  = "Unknown"
+)
+
+// Values of the AlertsQuery Statuses and MessageTypes filters. The API wants them
+// lowercase but capitalizes the Alert fields, e.g. "Actual" and "Alert", so compare
+// those with Alert.IsStatus and Alert.IsMessageType.
+const (
+	AlertStatusActual   = "actual"
+	AlertStatusExercise = "exercise"
+	AlertStatusSystem   = "system"
+	AlertStatusTest     = "test"
+	AlertStatusDraft    = "draft"
+
+	AlertMessageAlert  = "alert"
+	AlertMessageUpdate = "update"
+	AlertMessageCancel = "cancel"
+)
+
+// AlertsQuery selects the alerts returned by Alerts. At most one of
+// Point, Zones, Areas and Region may be set, the others are filters.
+type AlertsQuery struct {
+	// Active only returns the alerts in effect, using /alerts/active
+	Active bool
+	// Point returns the alerts covering a location
+	Point *LatLon
+	// Zones are forecast or county zone IDs, e.g. "WAZ558" or "WAC033"
+	Zones []string
+	// Areas are state or marine area codes, e.g. "WA" or "PZ"
+	Areas []string
+	// Region is a marine region code, e.g. "PA"
+	Region string
+
+	Events       []string
+	Severities   []string
+	Urgencies    []string
+	Certainties  []string
+	Statuses     []string
+	MessageTypes []string
+	// Start and End limit the time range of the alerts, zero leaves it open
+	Start time.Time
+	End   time.Time
+	// Limit caps the number of alerts returned, <= 0 returns all of them
+	Limit int
+}
+
+// values encodes the query parameters
+func (q AlertsQuery) values() (url.Values, error) {
+	locations := 0
+	for _, set := range []bool{q.Point != nil, len(q.Zones) > 0, len(q.Areas) > 0, q.Region != ""} {
+		if set {
+			locations++
+		}
+	}
+	if locations > 1 {
+		return nil, fmt.Errorf("alerts query can only use one of point, zones, areas and region")
+	}
+	values := url.Values{}
+	if q.Point != nil {
+		values.Set("point", q.Point.String())
+	}
+	lists := map[string][]string{
+		"zone":         q.Zones,
+		"area":         q.Areas,
+		"event":        q.Events,
+		"severity":     q.Severities,
+		"urgency":      q.Urgencies,
+		"certainty":    q.Certainties,
+		"status":       q.Statuses,
+		"message_type": q.MessageTypes,
+	}
+	for key, list := range lists {
+		if len(list) > 0 {
+			values.Set(key, strings.Join(list, ","))
+		}
+	}
+	if q.Region != "" {
+		values.Set("region", q.Region)
+	}
+	if !q.Start.IsZero() {
+		values.Set("start", q.Start.UTC().Format(timeFormat))
+	}
+	if !q.End.IsZero() {
+		values.Set("end", q.End.UTC().Format(timeFormat))
+	}
+	if q.Limit > 0 && !q.Active {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return values, nil
+}
+
+// endpoint is the /alerts URL of the query
+func (q AlertsQuery) endpoint(baseURL string) (string, error) {
+	values, err := q.values()
+	if err != nil {
+		return "", err
+	}
+	endpoint := baseURL + "/alerts"
+	if q.Active {
+		endpoint += "/active"
+	}
+	if len(values) > 0 {
+		endpoint += "?" + values.Encode()
+	}
+	return endpoint, nil
+}
+
+// AlertReference identifies an earlier alert updated or cancelled by an alert
+type AlertReference struct {
+	ID     string    `json:"identifier"`
+	URL    string    `json:"@id"`
+	Sender string    `json:"sender"`
+	Sent   time.Time `json:"sent"`
+}
+
+// Alert holds the JSON values of an alert from /alerts
+type Alert struct {
+	ID       string `json:"id"`
+	URL      string `json:"@id"`
+	AreaDesc string `json:"areaDesc"`
+	Geometry string `json:"geometry"`
+	Geocode  struct {
+		SAME []string `json:"SAME"`
+		UGC  []string `json:"UGC"`
+	} `json:"geocode"`
+	AffectedZones []string         `json:"affectedZones"`
+	References    []AlertReference `json:"references"`
+
+	Sent      time.Time  `json:"sent"`
+	Effective time.Time  `json:"effective"`
+	Onset     *time.Time `json:"onset"`
+	Expires   *time.Time `json:"expires"`
+	Ends      *time.Time `json:"ends"`
+
+	Status      string `json:"status"`
+	MessageType string `json:"messageType"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Certainty   string `json:"certainty"`
+	Urgency     string `json:"urgency"`
+	Event       string `json:"event"`
+	Sender      string `json:"sender"`
+	SenderName  string `json:"senderName"`
+	Headline    string `json:"headline"`
+	Description string `json:"description"`
+	Instruction string `json:"instruction"`
+	Response    string `json:"response"`
+	// Parameters holds extra values, e.g. "VTEC", "NWSheadline" or "eventEndingTime"
+	Parameters map[string][]string `json:"parameters"`
+
+	// Polygons is parsed from Geometry, empty when the alert only lists zones
+	Polygons []Polygon `json:"-"`
+	// VTEC is parsed from Parameters["VTEC"]
+	VTEC []VTEC `json:"-"`
+	// ParseErrors lists the geometry and VTEC codes left out of Polygons and VTEC
+	ParseErrors []error `json:"-"`
+}
+
+// UnmarshalJSON decodes the alert and parses its geometry and VTEC codes.
+// Values that cannot be parsed are skipped and recorded in ParseErrors
+// so one malformed alert does not fail a whole page.
+func (a *Alert) UnmarshalJSON(buf []byte) error {
+	type alert Alert
+	if err := json.Unmarshal(buf, (*alert)(a)); err != nil {
+		return err
+	}
+	a.Polygons = nil
+	a.ParseErrors = nil
+	if a.Geometry != "" {
+		polygons, err := parseWKTPolygons(a.Geometry)
+		if err != nil {
+			a.ParseErrors = append(a.ParseErrors, fmt.Errorf("alert %s: %s", a.ID, err.Error()))
+		}
+		a.Polygons = polygons
+	}
+	a.VTEC = nil
+	for _, code := range a.Parameters["VTEC"] {
+		vtec, err := ParseVTEC(code)
+		if err != nil {
+			a.ParseErrors = append(a.ParseErrors, fmt.Errorf("alert %s: %s", a.ID, err.Error()))
+			continue
+		}
+		a.VTEC = append(a.VTEC, vtec)
+	}
+	return nil
+}
+
+// IsStatus is true when the alert has status, one of the AlertStatus values, in any case
+func (a *Alert) IsStatus(status string) bool {
+	return strings.EqualFold(a.Status, status)
+}
+
+// IsMessageType is true when the alert has messageType, one of the AlertMessage values, in any case
+func (a *Alert) IsMessageType(messageType string) bool {
+	return strings.EqualFold(a.MessageType, messageType)
+}
+
+// Contains is true when p is inside the alert geometry
+func (a *Alert) Contains(p LatLon) bool {
+	for _, polygon := range a.Polygons {
+		if polygon.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// VTEC is a parsed Valid Time Event Code, e.g. "/O.NEW.KSEW.WS.A.0005.191028T0600Z-191029T0600Z/"
+type VTEC struct {
+	// Product class, "O" for operational
+	Product string
+	// Action, e.g. "NEW", "CON", "EXT", "UPG", "CAN" or "EXP"
+	Action string
+	// Office issuing the event, e.g. "KSEW"
+	Office string
+	// Hazard holds the phenomenon, significance and event number
+	Hazard Hazard
+	// Start and End of the event, zero when not given
+	Start time.Time
+	End   time.Time
+}
+
+var vtecRegex = regexp.MustCompile(
+	`^/?([OTEX])\.([A-Z]{3})\.([A-Z]{4})\.([A-Z]{2})\.([A-Z])\.(\d{4})\.(\d{6}T\d{4}Z)-(\d{6}T\d{4}Z)/?$`)
+
+const vtecTimeFormat = "060102T1504Z"
+
+// ParseVTEC parses a P-VTEC string
+func ParseVTEC(s string) (VTEC, error) {
+	matches := vtecRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return VTEC{}, fmt.Errorf("invalid VTEC %q", s)
+	}
+	eventNumber, _ := strconv.Atoi(matches[6])
+	times := make([]time.Time, 2)
+	for i, value := range matches[7:9] {
+		if value == "000000T0000Z" {
+			continue
+		}
+		t, err := time.Parse(vtecTimeFormat, value)
+		if err != nil {
+			return VTEC{}, fmt.Errorf("invalid VTEC %q: %s", s, err.Error())
+		}
+		times[i] = t
+	}
+	return VTEC{
+		Product: matches[1],
+		Action:  matches[2],
+		Office:  matches[3],
+		Hazard: Hazard{
+			Phenomenon:   matches[4],
+			Significance: matches[5],
+			EventNumber:  &eventNumber,
+		},
+		Start: times[0],
+		End:   times[1],
+	}, nil
+}
+
+// alertsResponse holds the JSON values from /alerts
+type alertsResponse struct {
+	Alerts     []*Alert `json:"@graph"`
+	Pagination struct {
+		Next string `json:"next"`
+	} `json:"pagination"`
+}
+
+// Alerts returns the alerts matching query, following the pages of results
+func (c *Client) Alerts(query AlertsQuery) ([]*Alert, error) {
+	return c.AlertsContext(context.Background(), query)
+}
+
+// AlertsContext is Alerts with a context that cancels the requests
+func (c *Client) AlertsContext(ctx context.Context, query AlertsQuery) ([]*Alert, error) {
+	endpoint, err := query.endpoint(c.baseURL())
+	if err != nil {
+		return nil, err
+	}
+	alerts := make([]*Alert, 0)
+	for endpoint != "" {
+		page, err := c.alertsPage(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, page.Alerts...)
+		if query.Limit > 0 && len(alerts) >= query.Limit {
+			return alerts[:query.Limit], nil
+		}
+		// the last page still links to a next, empty, page
+		if len(page.Alerts) == 0 || page.Pagination.Next == endpoint {
+			break
+		}
+		endpoint = page.Pagination.Next
+	}
+	return alerts, nil
+}
+
+func (c *Client) alertsPage(ctx context.Context, endpoint string) (page *alertsResponse, err error) {
+	res, err := c.apiCall(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	if err = decoder.Decode(&page); err != nil {
+		return nil, err
+	}
+	c.logParseErrors(page.Alerts)
+	return page, nil
+}
+
+// logParseErrors logs the values skipped while decoding alerts
+func (c *Client) logParseErrors(alerts []*Alert) {
+	for _, alert := range alerts {
+		for _, err := range alert.ParseErrors {
+			c.logf("skipped %s", err)
+		}
+	}
+}
+
+// Alerts calls Client.Alerts on the DefaultClient
+func Alerts(query AlertsQuery) ([]*Alert, error) {
+	return DefaultClient.Alerts(query)
+}
+
+// AlertsContext calls Client.AlertsContext on the DefaultClient
+func AlertsContext(ctx context.Context, query AlertsQuery) ([]*Alert, error) {
+	return DefaultClient.AlertsContext(ctx, query)
+}
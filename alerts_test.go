@@ -0,0 +1,161 @@
+package noaa
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAlert = `{
+	"@id": "%[1]s/alerts/urn:oid:2.49.0.1.840.0.%[2]s",
+	"id": "urn:oid:2.49.0.1.840.0.%[2]s",
+	"areaDesc": "Cascades of King County",
+	"geometry": "POLYGON((-122 47, -121 47, -121 48, -122 48, -122 47))",
+	"geocode": {"SAME": ["053033"], "UGC": ["WAZ568"]},
+	"affectedZones": ["%[1]s/zones/forecast/WAZ568"],
+	"references": [],
+	"sent": "2019-10-27T14:00:00-07:00",
+	"effective": "2019-10-27T14:00:00-07:00",
+	"onset": "2019-10-27T23:00:00-07:00",
+	"expires": "2019-10-28T06:00:00-07:00",
+	"ends": null,
+	"status": "Actual",
+	"messageType": "Alert",
+	"category": "Met",
+	"severity": "Severe",
+	"certainty": "Possible",
+	"urgency": "Future",
+	"event": "Winter Storm Watch",
+	"headline": "Winter Storm Watch issued October 27",
+	"description": "HEAVY SNOW POSSIBLE",
+	"instruction": "Monitor the latest forecasts",
+	"parameters": {
+		"NWSheadline": ["WINTER STORM WATCH IN EFFECT FROM LATE TONIGHT THROUGH MONDAY"],
+		"VTEC": ["/O.NEW.KSEW.WS.A.0005.191028T0600Z-000000T0000Z/"]
+	}
+}`
+
+func newAlertsServer(queries *[]string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			alert := fmt.Sprintf(testAlert, server.URL, "1")
+			fmt.Fprintf(w, `{"@graph": [%s], "pagination": {"next": "%s/alerts?cursor=2"}}`, alert, server.URL)
+		case "2":
+			alert := fmt.Sprintf(testAlert, server.URL, "2")
+			fmt.Fprintf(w, `{"@graph": [%s], "pagination": {"next": "%s/alerts?cursor=3"}}`, alert, server.URL)
+		default:
+			fmt.Fprintf(w, `{"@graph": [], "pagination": {"next": "%s/alerts?cursor=4"}}`, server.URL)
+		}
+	}))
+	return server
+}
+
+func TestAlertsPagination(t *testing.T) {
+	queries := []string{}
+	server := newAlertsServer(&queries)
+	defer server.Close()
+	client := newTestClient(server)
+
+	alerts, err := client.Alerts(AlertsQuery{
+		Areas:      []string{"WA"},
+		Severities: []string{AlertSeveritySevere, AlertSeverityExtreme},
+		Statuses:   []string{AlertStatusActual},
+	})
+	check(err)
+	assert.Equal(t, 2, len(alerts))
+	assert.Equal(t, []string{"area=WA&severity=Severe%2CExtreme&status=actual", "cursor=2", "cursor=3"}, queries)
+
+	alert := alerts[0]
+	assert.Equal(t, "urn:oid:2.49.0.1.840.0.1", alert.ID)
+	assert.Equal(t, "Winter Storm Watch", alert.Event)
+	assert.Equal(t, AlertSeveritySevere, alert.Severity)
+	assert.Equal(t, "Actual", alert.Status)
+	assert.True(t, alert.IsStatus(AlertStatusActual))
+	assert.False(t, alert.IsStatus(AlertStatusTest))
+	assert.True(t, alert.IsMessageType(AlertMessageAlert))
+	assert.False(t, alert.IsMessageType(AlertMessageCancel))
+	assert.Equal(t, time.Date(2019, 10, 28, 6, 0, 0, 0, time.UTC), alert.Onset.UTC())
+	assert.Nil(t, alert.Ends)
+	assert.Equal(t, []string{"WAZ568"}, alert.Geocode.UGC)
+	assert.True(t, alert.Contains(LatLon{Lat: 47.5, Lon: -121.5}))
+	assert.False(t, alert.Contains(LatLon{Lat: 47.6, Lon: -122.3}))
+
+	assert.Equal(t, 1, len(alert.VTEC))
+	vtec := alert.VTEC[0]
+	assert.Equal(t, "NEW", vtec.Action)
+	assert.Equal(t, "KSEW", vtec.Office)
+	assert.Equal(t, "Winter Storm Watch", vtec.Hazard.Name())
+	assert.Equal(t, 5, *vtec.Hazard.EventNumber)
+	assert.Equal(t, time.Date(2019, 10, 28, 6, 0, 0, 0, time.UTC), vtec.Start)
+	assert.True(t, vtec.End.IsZero())
+
+	queries = queries[:0]
+	alerts, err = client.Alerts(AlertsQuery{Point: &LatLon{Lat: 47.5, Lon: -121.5}, Limit: 1})
+	check(err)
+	assert.Equal(t, 1, len(alerts))
+	assert.Equal(t, []string{"limit=1&point=47.5%2C-121.5"}, queries)
+
+	_, err = client.Alerts(AlertsQuery{Point: &LatLon{}, Zones: []string{"WAZ558"}})
+	assert.Error(t, err)
+}
+
+func TestAlertsMalformed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		badVTEC := strings.Replace(fmt.Sprintf(testAlert, "", "1"), "/O.NEW.KSEW", "/O.BAD", 1)
+		badGeometry := strings.Replace(fmt.Sprintf(testAlert, "", "2"), "POLYGON((", "CIRCLE((", 1)
+		valid := fmt.Sprintf(testAlert, "", "3")
+		fmt.Fprintf(w, `{"@graph": [%s, %s, %s], "pagination": {}}`, badVTEC, badGeometry, valid)
+	}))
+	defer server.Close()
+	client := newTestClient(server)
+
+	alerts, err := client.Alerts(AlertsQuery{Active: true})
+	check(err)
+	assert.Equal(t, 3, len(alerts))
+	// a bad value only empties its own field
+	assert.Empty(t, alerts[0].VTEC)
+	assert.Equal(t, 1, len(alerts[0].Polygons))
+	assert.Equal(t, 1, len(alerts[0].ParseErrors))
+	assert.Empty(t, alerts[1].Polygons)
+	assert.Equal(t, 1, len(alerts[1].VTEC))
+	assert.Equal(t, 1, len(alerts[1].ParseErrors))
+	assert.Equal(t, 1, len(alerts[2].VTEC))
+	assert.Empty(t, alerts[2].ParseErrors)
+}
+
+func TestAlertsQueryEndpoint(t *testing.T) {
+	endpoint, err := AlertsQuery{Active: true, Zones: []string{"WAZ558", "WAC033"}, Limit: 10}.endpoint(API)
+	check(err)
+	assert.Equal(t, API+"/alerts/active?zone=WAZ558%2CWAC033", endpoint)
+	endpoint, err = AlertsQuery{
+		Region:       "PA",
+		Events:       []string{"Gale Warning"},
+		MessageTypes: []string{AlertMessageAlert, AlertMessageUpdate},
+		Start:        time.Date(2019, 10, 27, 0, 0, 0, 0, time.UTC),
+	}.endpoint(API)
+	check(err)
+	assert.Equal(t, API+"/alerts?event=Gale+Warning&message_type=alert%2Cupdate&region=PA&start=2019-10-27T00%3A00%3A00Z", endpoint)
+}
+
+func TestParseVTEC(t *testing.T) {
+	vtec, err := ParseVTEC("/O.EXT.KSEW.FW.W.0012.191027T1800Z-191028T0300Z/")
+	check(err)
+	assert.Equal(t, "O", vtec.Product)
+	assert.Equal(t, "EXT", vtec.Action)
+	assert.Equal(t, "Red Flag Warning", vtec.Hazard.Name())
+	assert.Equal(t, SeverityWarning, vtec.Hazard.Severity())
+	assert.Equal(t, 9*time.Hour, vtec.End.Sub(vtec.Start))
+
+	for _, s := range []string{"", "/O.NEW.KSEW.WS.A/", "/O.NEW.KSEW.WS.A.0005.191328T0600Z-000000T0000Z/"} {
+		_, err := ParseVTEC(s)
+		assert.Error(t, err, s)
+	}
+}
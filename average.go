@@ -2,7 +2,10 @@ package noaa
 
 import (
 	"fmt"
+	"math"
 	"time"
+
+	"github.com/adamgreenhall/noaa/units"
 )
 
 // AverageForecast takes the mean between many ForecastGridResponse
@@ -11,17 +14,18 @@ func AverageForecast(forecasts []*ForecastGridResponse, debug bool) (*ForecastGr
 		return nil, fmt.Errorf("no forecasts to average")
 	}
 	N := float64(len(forecasts))
-	tsMin := forecasts[0].Temperature.Values[0].Time.Time
-	tsMax := forecasts[0].Temperature.Values[0].Time.Time
+	tsMin := forecasts[0].Temperature.Values[0].Time.Snap(time.Hour).Time
+	tsMax := forecasts[0].Temperature.Values[0].Time.Snap(time.Hour).Time
 	baseElevationUnits := forecasts[0].Elevation.Units
 	meanElevation := 0.0
 	timeseriesArrays := make(map[string][]*ForecastTimeseries, 0)
 	meanTimeseries := make(map[string]*ForecastTimeseries, 0)
 	for i, fcst := range forecasts {
-		if fcst.Elevation.Units != baseElevationUnits {
-			return nil, fmt.Errorf("elevation units must match. units[i=%d] %s != %s", i, fcst.Elevation.Units, baseElevationUnits)
+		elevation, err := units.ConvertCode(fcst.Elevation.Value, fcst.Elevation.Units, baseElevationUnits)
+		if err != nil {
+			return nil, fmt.Errorf("cannot reconcile elevation units[i=%d] %s with %s. %s", i, fcst.Elevation.Units, baseElevationUnits, err.Error())
 		}
-		meanElevation += fcst.Elevation.Value / N
+		meanElevation += elevation / N
 		if debug {
 			fmt.Println(fmt.Sprintf("%d %26s %s %s", i, "fcst", fcst.ValidTimes.Time.Format(timeFormat), fcst.ValidTimes.endTime().Format(timeFormat)))
 		}
@@ -31,11 +35,12 @@ func AverageForecast(forecasts []*ForecastGridResponse, debug bool) (*ForecastGr
 				fmt.Println(fmt.Sprintf("%d %26s %s %s", i, k, ts.Tmin().Format(timeFormat), ts.Tmax().Format(timeFormat)))
 			}
 		}
-		if fcst.ValidTimes.Time.Before(tsMin) {
-			tsMin = fcst.ValidTimes.Time
+		validTimes := fcst.ValidTimes.Snap(time.Hour)
+		if validTimes.Time.Before(tsMin) {
+			tsMin = validTimes.Time
 		}
-		if fcst.ValidTimes.endTime().After(tsMax) {
-			tsMax = fcst.ValidTimes.endTime()
+		if validTimes.endTime().After(tsMax) {
+			tsMax = validTimes.endTime()
 		}
 	}
 	if debug {
@@ -48,7 +53,7 @@ func AverageForecast(forecasts []*ForecastGridResponse, debug bool) (*ForecastGr
 		}
 		meanTimeseries[k] = tsMean
 	}
-	return newForecastGridResponse(
+	grid, err := newForecastGridResponse(
 		forecasts[0].Updated,
 		&ForecastTime{tsMin, tsMax.Sub(tsMin)},
 		forecastElevation{
@@ -57,15 +62,26 @@ func AverageForecast(forecasts []*ForecastGridResponse, debug bool) (*ForecastGr
 		},
 		meanTimeseries,
 	)
+	if err != nil {
+		return nil, err
+	}
+	grid.Timezone = forecasts[0].Timezone
+	return grid, nil
 }
 
 func averageForecastTimeseries(key string, forecasts []*ForecastTimeseries, tsMin time.Time, tsMax time.Time, rootForecasts []*ForecastGridResponse) (*ForecastTimeseries, error) {
 	N := float64(len(forecasts))
-	fcstBase, err := forecasts[0].hourly(tsMin, tsMax)
+	// totals like precipitation are split across the hours, not repeated
+	method := DefaultResampleMethod(key)
+	fcstBase, err := forecasts[0].hourlyMethod(tsMin, tsMax, method)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert forecast[0]=%s to hourly.\n%s", rootForecasts[0].ID, err.Error())
 	}
 	baseUnits := fcstBase.Units
+	// directions are averaged on the circle, 350 and 10 degrees give 0
+	angle := isAngle(baseUnits)
+	sin := make([]float64, len(fcstBase.Values))
+	cos := make([]float64, len(fcstBase.Values))
 	avgValues := make([]*ForecastTimeseriesValue, len(fcstBase.Values))
 	// convert each of these ts to hourly timeseries (currently irregular)
 	for i, elem := range fcstBase.Values {
@@ -73,9 +89,13 @@ func averageForecastTimeseries(key string, forecasts []*ForecastTimeseries, tsMi
 	}
 	for i, fcst := range forecasts {
 		if fcst.Units != baseUnits {
-			return nil, fmt.Errorf("units must match units[i=%d] %s != %s", i, fcst.Units, baseUnits)
+			converted, err := fcst.Convert(baseUnits)
+			if err != nil {
+				return nil, fmt.Errorf("cannot reconcile units[i=%d] %s with %s. %s", i, fcst.Units, baseUnits, err.Error())
+			}
+			fcst = converted
 		}
-		fcstHourly, err := fcst.hourly(tsMin, tsMax)
+		fcstHourly, err := fcst.hourlyMethod(tsMin, tsMax, method)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert forecast[%d]=%s to hourly. %s", i, rootForecasts[i].ID, err.Error())
 		}
@@ -103,8 +123,38 @@ func averageForecastTimeseries(key string, forecasts []*ForecastTimeseries, tsMi
 					rootForecasts[i].ID,
 				)
 			}
+			if angle {
+				sin[e] += math.Sin(elem.Value * math.Pi / 180)
+				cos[e] += math.Cos(elem.Value * math.Pi / 180)
+				continue
+			}
 			avgValues[e].Value += elem.Value / N
 		}
 	}
+	if angle {
+		for e := range avgValues {
+			avgValues[e].Value = circularMean(sin[e], cos[e])
+		}
+	}
 	return &ForecastTimeseries{Units: baseUnits, Values: avgValues}, nil
 }
+
+// isAngle is true for the units of direction layers, e.g. wmoUnit:degree_(angle)
+func isAngle(code string) bool {
+	unit, err := units.Parse(code)
+	return err == nil && unit.Dimension == units.Angle
+}
+
+// circularMean is the mean direction in [0, 360) degrees of the summed sines and cosines
+func circularMean(sin float64, cos float64) float64 {
+	degrees := math.Atan2(sin, cos) * 180 / math.Pi
+	if degrees < 0 {
+		degrees += 360
+	}
+	// round off the float error of directions at 0 degrees
+	degrees = math.Round(degrees*1e9) / 1e9
+	if degrees >= 360 {
+		degrees -= 360
+	}
+	return degrees
+}
@@ -64,6 +64,60 @@ func TestAverage(t *testing.T) {
 	assert.Equal(t, fcstAvg.Temperature.Values[0].Value, (forecasts[0].Temperature.Values[0].Value+forecasts[1].Temperature.Values[0].Value)/2.0)
 }
 
+func TestAverageMixedUnits(t *testing.T) {
+	fcst1, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	fcst2, err := readForecast("test_cases/gridForecast2.json")
+	check(err)
+	expected := (fcst1.Temperature.Values[0].Value + fcst2.Temperature.Values[0].Value) / 2.0
+	fcst2.Temperature, err = fcst2.Temperature.Convert("unit:degF")
+	check(err)
+	fcst2.Elevation.Value = fcst2.Elevation.Value / 0.3048
+	fcst2.Elevation.Units = "unit:ft"
+
+	fcstAvg, err := AverageForecast([]*ForecastGridResponse{fcst1, fcst2}, false)
+	check(err)
+	assert.Equal(t, "unit:degC", fcstAvg.Temperature.Units)
+	assert.InDelta(t, expected, fcstAvg.Temperature.Values[0].Value, 1e-9)
+	assert.Equal(t, "unit:m", fcstAvg.Elevation.Units)
+}
+
+func TestAveragePrecipitationTotal(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	total := 0.0
+	for _, v := range fcst.PrecipitationQuantity.Values {
+		total += v.Value
+	}
+	fcstAvg, err := AverageForecast([]*ForecastGridResponse{fcst, fcst}, false)
+	check(err)
+	avgTotal := 0.0
+	for _, v := range fcstAvg.PrecipitationQuantity.Values {
+		avgTotal += v.Value
+	}
+	assert.InDelta(t, total, avgTotal, 1e-9)
+}
+
+func TestAverageWindDirection(t *testing.T) {
+	fcst1, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	fcst2, err := readForecast("test_cases/gridForecast2.json")
+	check(err)
+	for _, v := range fcst1.WindDirection.Values {
+		v.Value = 350
+	}
+	for _, v := range fcst2.WindDirection.Values {
+		v.Value = 10
+	}
+	fcstAvg, err := AverageForecast([]*ForecastGridResponse{fcst1, fcst2}, false)
+	check(err)
+	for _, v := range fcstAvg.WindDirection.Values {
+		assert.InDelta(t, 0, v.Value, 1e-9)
+	}
+	// 270 and 0 degrees
+	assert.InDelta(t, 315, circularMean(-1, 1), 1e-9)
+}
+
 func TestAverageEnd2End(t *testing.T) {
 	var endpoints = [...]string{
 		"https://api.weather.gov/gridpoints/SEW/151,119",
@@ -0,0 +1,302 @@
+package noaa
+
+import (
+	"container/list"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults used by NewClient for its points cache
+const (
+	DefaultCacheSize = 1024
+	DefaultCacheTTL  = 24 * time.Hour
+)
+
+// PointsCache stores PointsResponse values keyed by their /points endpoint.
+// Implementations must be safe for concurrent use.
+type PointsCache interface {
+	// Get returns the cached points or false when missing or expired
+	Get(key string) (*PointsResponse, bool)
+	// Set stores points for ttl, a ttl of zero uses the cache default
+	// and a negative ttl means the value must not be stored
+	Set(key string, points *PointsResponse, ttl time.Duration)
+}
+
+// lru is a concurrency safe least recently used cache with expiring entries
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	order *list.List
+	items map[string]*list.Element
+	now   func() time.Time
+}
+
+type lruEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+func newLRU(size int, ttl time.Duration) *lru {
+	return &lru{
+		size:  size,
+		ttl:   ttl,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+		now:   time.Now,
+	}
+}
+
+func (c *lru) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry)
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *lru) set(key string, value interface{}, ttl time.Duration) {
+	if ttl < 0 {
+		return
+	}
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.now().Add(ttl)
+	}
+	c.setExpires(key, value, expires)
+}
+
+func (c *lru) setExpires(key string, value interface{}, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		elem.Value = &lruEntry{key: key, value: value, expires: expires}
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (c *lru) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// entries returns the unexpired entries from most to least recently used
+func (c *lru) entries() []*lruEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	out := make([]*lruEntry, 0, c.order.Len())
+	for elem := c.order.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*lruEntry)
+		if entry.expires.IsZero() || now.Before(entry.expires) {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+// LRUCache is the default PointsCache. It holds at most size entries,
+// evicting the least recently used, and drops entries older than their TTL.
+type LRUCache struct {
+	cache *lru
+}
+
+// NewLRUCache creates an LRUCache. A size of zero is unbounded and a ttl of
+// zero keeps entries until they are evicted.
+func NewLRUCache(size int, ttl time.Duration) *LRUCache {
+	return &LRUCache{cache: newLRU(size, ttl)}
+}
+
+// Get returns the cached points or false when missing or expired
+func (c *LRUCache) Get(key string) (*PointsResponse, bool) {
+	value, ok := c.cache.get(key)
+	if !ok {
+		return nil, false
+	}
+	return value.(*PointsResponse), true
+}
+
+// Set stores points for ttl, see PointsCache
+func (c *LRUCache) Set(key string, points *PointsResponse, ttl time.Duration) {
+	c.cache.set(key, points, ttl)
+}
+
+// Len is the number of entries, including expired ones not yet dropped
+func (c *LRUCache) Len() int {
+	return c.cache.len()
+}
+
+type lruCacheFileEntry struct {
+	Key     string          `json:"key"`
+	Points  *PointsResponse `json:"points"`
+	Expires time.Time       `json:"expires"`
+}
+
+// SaveFile writes the unexpired entries to path as JSON
+// so they can be restored with LoadFile in a later run
+func (c *LRUCache) SaveFile(path string) error {
+	entries := c.cache.entries()
+	out := make([]lruCacheFileEntry, len(entries))
+	for i, entry := range entries {
+		out[i] = lruCacheFileEntry{
+			Key:     entry.key,
+			Points:  entry.value.(*PointsResponse),
+			Expires: entry.expires,
+		}
+	}
+	buf, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf, 0644)
+}
+
+// LoadFile adds the unexpired entries saved by SaveFile to the cache
+func (c *LRUCache) LoadFile(path string) error {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var entries []lruCacheFileEntry
+	if err = json.Unmarshal(buf, &entries); err != nil {
+		return err
+	}
+	now := c.cache.now()
+	// oldest first so the most recently used entry ends up in front
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.Points == nil || (!entry.Expires.IsZero() && !now.Before(entry.Expires)) {
+			continue
+		}
+		c.cache.setExpires(entry.Key, entry.Points, entry.Expires)
+	}
+	return nil
+}
+
+// cacheTTL reads the freshness lifetime from the Cache-Control and Expires headers.
+// It returns zero when the response does not say and a negative value when
+// the response must not be cached.
+func cacheTTL(header http.Header) time.Duration {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store" || directive == "no-cache":
+			return -1
+		case strings.HasPrefix(directive, "max-age="):
+			seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err != nil {
+				continue
+			}
+			if seconds <= 0 {
+				return -1
+			}
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	if expiresHeader := header.Get("Expires"); expiresHeader != "" {
+		expires, err := http.ParseTime(expiresHeader)
+		if err != nil {
+			// invalid dates mean already expired
+			return -1
+		}
+		now := time.Now()
+		if date, err := http.ParseTime(header.Get("Date")); err == nil {
+			now = date
+		}
+		if !expires.After(now) {
+			return -1
+		}
+		return expires.Sub(now)
+	}
+	return 0
+}
+
+// ConditionalCache keeps responses with their ETag and Last-Modified
+// validators so repeated requests can be answered with 304 Not Modified.
+type ConditionalCache struct {
+	cache *lru
+}
+
+type conditionalEntry struct {
+	etag         string
+	lastModified string
+	value        interface{}
+}
+
+// NewConditionalCache creates a ConditionalCache holding at most size responses, zero is unbounded
+func NewConditionalCache(size int) *ConditionalCache {
+	return &ConditionalCache{cache: newLRU(size, 0)}
+}
+
+// Len is the number of cached responses
+func (c *ConditionalCache) Len() int {
+	return c.cache.len()
+}
+
+func (c *ConditionalCache) get(key string) (*conditionalEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+	value, ok := c.cache.get(key)
+	if !ok {
+		return nil, false
+	}
+	return value.(*conditionalEntry), true
+}
+
+// set stores value if the response carries a validator
+func (c *ConditionalCache) set(key string, header http.Header, value interface{}) {
+	if c == nil {
+		return
+	}
+	entry := &conditionalEntry{
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		value:        value,
+	}
+	if entry.etag == "" && entry.lastModified == "" {
+		return
+	}
+	c.cache.set(key, entry, 0)
+}
+
+// header returns the If-None-Match / If-Modified-Since headers revalidating the entry
+func (e *conditionalEntry) header() http.Header {
+	if e == nil {
+		return nil
+	}
+	header := http.Header{}
+	if e.etag != "" {
+		header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		header.Set("If-Modified-Since", e.lastModified)
+	}
+	return header
+}
@@ -0,0 +1,148 @@
+package noaa
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2, time.Hour)
+	cache.Set("a", &PointsResponse{CWA: "A"}, 0)
+	cache.Set("b", &PointsResponse{CWA: "B"}, 0)
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+	cache.Set("c", &PointsResponse{CWA: "C"}, 0)
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "b was least recently used")
+	points, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "A", points.CWA)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestLRUCacheTTL(t *testing.T) {
+	now := time.Date(2020, 8, 19, 0, 0, 0, 0, time.UTC)
+	cache := NewLRUCache(0, time.Hour)
+	cache.cache.now = func() time.Time { return now }
+	cache.Set("default", &PointsResponse{}, 0)
+	cache.Set("short", &PointsResponse{}, time.Minute)
+	cache.Set("never", &PointsResponse{}, -1)
+
+	_, ok := cache.Get("never")
+	assert.False(t, ok)
+	now = now.Add(30 * time.Minute)
+	_, ok = cache.Get("short")
+	assert.False(t, ok)
+	_, ok = cache.Get("default")
+	assert.True(t, ok)
+	now = now.Add(30 * time.Minute)
+	_, ok = cache.Get("default")
+	assert.False(t, ok)
+}
+
+func TestLRUCacheConcurrent(t *testing.T) {
+	cache := NewLRUCache(16, time.Hour)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := fmt.Sprintf("%d", (i*j)%32)
+				cache.Set(key, &PointsResponse{}, 0)
+				cache.Get(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.True(t, cache.Len() <= 16)
+}
+
+func TestLRUCacheFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "noaa")
+	check(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "points.json")
+
+	cache := NewLRUCache(0, time.Hour)
+	cache.Set("a", &PointsResponse{CWA: "A", GridX: 1}, 0)
+	cache.Set("b", &PointsResponse{CWA: "B"}, 0)
+	check(cache.SaveFile(path))
+
+	restored := NewLRUCache(0, time.Hour)
+	check(restored.LoadFile(path))
+	points, ok := restored.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "A", points.CWA)
+	assert.Equal(t, int64(1), points.GridX)
+	assert.Equal(t, 2, restored.Len())
+}
+
+func TestCacheTTL(t *testing.T) {
+	header := http.Header{}
+	assert.Equal(t, time.Duration(0), cacheTTL(header))
+	header.Set("Cache-Control", "public, max-age=3600")
+	assert.Equal(t, time.Hour, cacheTTL(header))
+	header.Set("Cache-Control", "max-age=0")
+	assert.True(t, cacheTTL(header) < 0)
+	header.Set("Cache-Control", "no-store")
+	assert.True(t, cacheTTL(header) < 0)
+
+	header = http.Header{}
+	header.Set("Date", "Wed, 19 Aug 2020 04:00:00 GMT")
+	header.Set("Expires", "Wed, 19 Aug 2020 06:00:00 GMT")
+	assert.Equal(t, 2*time.Hour, cacheTTL(header))
+	header.Set("Expires", "0")
+	assert.True(t, cacheTTL(header) < 0)
+}
+
+func TestGridForecastRevalidation(t *testing.T) {
+	buf, err := ioutil.ReadFile("test_cases/gridForecast1.json")
+	check(err)
+	sent, notModified := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		sent++
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Sun, 27 Oct 2019 22:16:01 GMT")
+		w.Write(buf)
+	}))
+	defer server.Close()
+	client := newTestClient(server)
+	assert.Nil(t, client.GridCache)
+	client.GridCache = NewConditionalCache(8)
+
+	endpoint := server.URL + "/gridpoints/SEW/151,119"
+	first, err := client.GetEndpointGridForecast(endpoint)
+	check(err)
+	expected := first.Temperature.Values[0].Value
+	// edits of a returned forecast do not leak to later callers
+	first.Temperature.Values[0].Value = -100
+	second, err := client.GetEndpointGridForecast(endpoint)
+	check(err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 1, notModified)
+	assert.False(t, first == second)
+	assert.Equal(t, expected, second.Temperature.Values[0].Value)
+	assert.Equal(t, endpoint, second.ID)
+	assert.Equal(t, 1, client.GridCache.Len())
+
+	client.GridCache = nil
+	_, err = client.GetEndpointGridForecast(endpoint)
+	check(err)
+	assert.Equal(t, 2, sent)
+}
@@ -0,0 +1,184 @@
+package noaa
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client holds the configuration used to talk to the weather.gov API.
+// The zero value is usable and behaves like DefaultClient without a cache.
+type Client struct {
+	// BaseURL is the root of the API, defaults to API
+	BaseURL string
+	// HTTPClient performs the requests, defaults to http.DefaultClient
+	HTTPClient *http.Client
+	// UserAgent identifies the application, defaults to APIKey
+	UserAgent string
+	// Accept is the requested content type, defaults to APIAccept
+	Accept string
+	// Cache is used for point lookup to save some HTTP round trips.
+	// key is the /points endpoint, nil disables caching
+	Cache PointsCache
+	// GridCache revalidates gridpoint forecasts with ETag / Last-Modified.
+	// It is nil, disabled, unless set with NewConditionalCache.
+	GridCache *ConditionalCache
+	// Retry is the policy for transient failures, nil disables retries
+	Retry *RetryPolicy
+	// RateLimiter throttles every request made by the client, nil disables it
+	RateLimiter *RateLimiter
+	// Concurrency caps the parallel requests of bulk calls like
+	// GetEndpointGridForecasts, zero uses DefaultConcurrency
+	Concurrency int
+	// Logger receives a line per request, nil disables logging
+	Logger *log.Logger
+	// Units selects the units of the text forecasts, empty lets the API pick (UnitsUS)
+	Units ForecastUnits
+	// SkipServiceAreaCheck sends requests for points outside of ServiceAreas
+	// instead of failing with ErrOutOfCoverage
+	SkipServiceAreaCheck bool
+}
+
+// ForecastUnits is the units query parameter of the text forecasts
+type ForecastUnits string
+
+// Units of the text forecasts
+const (
+	UnitsUS ForecastUnits = "us"
+	UnitsSI ForecastUnits = "si"
+)
+
+// WithUnits returns a copy of the client requesting text forecasts in units,
+// e.g. client.WithUnits(noaa.UnitsSI).Forecast(lat, lon). The copy shares the caches.
+func (c *Client) WithUnits(units ForecastUnits) *Client {
+	out := *c
+	out.Units = units
+	return &out
+}
+
+// NewClient returns a Client with the default settings and its own points cache
+func NewClient() *Client {
+	retry := DefaultRetryPolicy
+	return &Client{
+		BaseURL:    API,
+		HTTPClient: http.DefaultClient,
+		UserAgent:  APIKey,
+		Accept:     APIAccept,
+		Cache:      NewLRUCache(DefaultCacheSize, DefaultCacheTTL),
+		Retry:      &retry,
+	}
+}
+
+// DefaultConcurrency is the number of parallel requests of bulk calls
+const DefaultConcurrency = 4
+
+// DefaultClient is used by the package level functions
+var DefaultClient = NewClient()
+
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return API
+	}
+	return strings.TrimSuffix(c.BaseURL, "/")
+}
+
+func (c *Client) concurrency() int {
+	if c.Concurrency <= 0 {
+		return DefaultConcurrency
+	}
+	return c.Concurrency
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+func (c *Client) userAgent() string {
+	if c.UserAgent == "" {
+		return APIKey
+	}
+	return c.UserAgent
+}
+
+func (c *Client) accept() string {
+	if c.Accept == "" {
+		return APIAccept
+	}
+	return c.Accept
+}
+
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, args...)
+	}
+}
+
+// Call the weather.gov API. We could just use http.Get() but
+// since we need to include some custom header values this helps.
+// Transient failures are retried according to c.Retry.
+func (c *Client) apiCall(ctx context.Context, endpoint string) (res *http.Response, err error) {
+	return c.apiCallHeader(ctx, endpoint, nil)
+}
+
+// apiCallHeader is apiCall with extra request headers. When the headers make
+// the request conditional a 304 Not Modified response is returned as well.
+func (c *Client) apiCallHeader(ctx context.Context, endpoint string, header http.Header) (res *http.Response, err error) {
+	if strings.HasPrefix(c.baseURL(), "https://") {
+		endpoint = strings.Replace(endpoint, "http://", "https://", -1)
+	}
+	maxAttempts := c.Retry.attempts()
+	for attempt := 1; ; attempt++ {
+		res, err = c.do(ctx, endpoint, header)
+		if err == nil && (res.StatusCode == 200 || (res.StatusCode == 304 && header != nil)) {
+			return res, nil
+		}
+		retry := retryable(res, err) && ctx.Err() == nil
+		wait := time.Duration(0)
+		if err == nil {
+			wait = retryAfter(res)
+			err = newAPIError(res, endpoint)
+			res.Body.Close()
+		}
+		if !retry || attempt >= maxAttempts {
+			if attempt > 1 {
+				err = &RetryError{Attempts: attempt, Err: err}
+			}
+			return nil, err
+		}
+		wait = c.Retry.delay(attempt, wait)
+		c.logf("retrying %s in %s after attempt %d: %s", endpoint, wait, attempt, err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &RetryError{Attempts: attempt, Err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
+}
+
+// do sends a single GET request once the rate limiter allows it
+func (c *Client) do(ctx context.Context, endpoint string, header http.Header) (*http.Response, error) {
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Add("Accept", c.accept())
+	req.Header.Add("User-Agent", c.userAgent()) // See http://www.weather.gov/documentation/services-web-api
+
+	c.logf("GET %s", endpoint)
+	return c.httpClient().Do(req)
+}
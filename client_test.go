@@ -0,0 +1,228 @@
+package noaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testServerMu guards the calls maps written by concurrent handlers
+var testServerMu sync.Mutex
+
+// newTestServer stands in for api.weather.gov, serving a single point
+// whose grid forecast is test_cases/gridForecast1.json
+func newTestServer(calls map[string]int) *httptest.Server {
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/points/47.6,-122.3", func(w http.ResponseWriter, r *http.Request) {
+		testServerMu.Lock()
+		calls[r.URL.Path]++
+		testServerMu.Unlock()
+		fmt.Fprintf(w, `{
+			"@id": "%[1]s/points/47.6,-122.3",
+			"cwa": "SEW",
+			"gridX": 151,
+			"gridY": 119,
+			"forecast": "%[1]s/gridpoints/SEW/151,119/forecast",
+			"forecastHourly": "%[1]s/gridpoints/SEW/151,119/forecast/hourly",
+			"forecastGridData": "%[1]s/gridpoints/SEW/151,119",
+			"observationStations": "%[1]s/gridpoints/SEW/151,119/stations",
+			"timeZone": "America/Los_Angeles",
+			"forecastZone": "%[1]s/zones/forecast/WAZ558",
+			"county": "%[1]s/zones/county/WAC033",
+			"fireWeatherZone": "%[1]s/zones/fire/WAZ654",
+			"geometry": "POINT(-122.3 47.6)",
+			"relativeLocation": {
+				"city": "Seattle",
+				"state": "WA",
+				"geometry": "POINT(-122.330062 47.603832)",
+				"distance": {"value": 2347.6, "unitCode": "wmoUnit:m"},
+				"bearing": {"value": 92, "unitCode": "wmoUnit:degree_(angle)"}
+			}
+		}`, server.URL)
+	})
+	mux.HandleFunc("/gridpoints/SEW/151,119", func(w http.ResponseWriter, r *http.Request) {
+		testServerMu.Lock()
+		calls[r.URL.Path]++
+		testServerMu.Unlock()
+		buf, err := ioutil.ReadFile("test_cases/gridForecast1.json")
+		check(err)
+		w.Write(buf)
+	})
+	mux.HandleFunc("/gridpoints/SEW/151,119/forecast", func(w http.ResponseWriter, r *http.Request) {
+		testServerMu.Lock()
+		calls[r.URL.Path]++
+		testServerMu.Unlock()
+		file := "test_cases/forecast.json"
+		if r.URL.Query().Get("units") == "si" {
+			file = "test_cases/forecastSI.json"
+		}
+		buf, err := ioutil.ReadFile(file)
+		check(err)
+		w.Write(buf)
+	})
+	mux.HandleFunc("/gridpoints/SEW/151,119/forecast/hourly", func(w http.ResponseWriter, r *http.Request) {
+		testServerMu.Lock()
+		calls[r.URL.Path]++
+		testServerMu.Unlock()
+		buf, err := ioutil.ReadFile("test_cases/forecastHourly.json")
+		check(err)
+		w.Write(buf)
+	})
+	mux.HandleFunc("/gridpoints/SEW/151,119/stations", func(w http.ResponseWriter, r *http.Request) {
+		testServerMu.Lock()
+		calls[r.URL.Path]++
+		testServerMu.Unlock()
+		fmt.Fprintf(w, `{
+			"@graph": [
+				{
+					"@id": "%[1]s/stations/KBFI",
+					"stationIdentifier": "KBFI",
+					"name": "Seattle, Boeing Field",
+					"geometry": "POINT(-122.31442 47.53789)",
+					"elevation": {"value": 6.096, "unitCode": "wmoUnit:m"},
+					"timeZone": "America/Los_Angeles",
+					"forecast": "%[1]s/zones/forecast/WAZ558",
+					"county": "%[1]s/zones/county/WAC033"
+				},
+				{
+					"@id": "%[1]s/stations/KSEA",
+					"stationIdentifier": "KSEA",
+					"name": "Seattle, Seattle-Tacoma International Airport",
+					"geometry": "POINT(-122.31442 47.44467)",
+					"elevation": {"value": 131.064, "unitCode": "wmoUnit:m"},
+					"timeZone": "America/Los_Angeles"
+				},
+				{
+					"@id": "%[1]s/stations/KRNT",
+					"stationIdentifier": "KRNT",
+					"name": "Renton Municipal Airport",
+					"geometry": "POINT(-122.1 47.6)"
+				},
+				{
+					"@id": "%[1]s/stations/KXXX",
+					"stationIdentifier": "KXXX",
+					"name": "Broken Geometry",
+					"geometry": "POINT EMPTY"
+				}
+			],
+			"observationStations": ["%[1]s/stations/KXXX", "%[1]s/stations/KBFI", "%[1]s/stations/KSEA", "%[1]s/stations/KRNT"]
+		}`, server.URL)
+	})
+	server = httptest.NewServer(mux)
+	return server
+}
+
+func newTestClient(server *httptest.Server) *Client {
+	client := NewClient()
+	client.BaseURL = server.URL
+	client.HTTPClient = server.Client()
+	return client
+}
+
+func TestClientStandIn(t *testing.T) {
+	calls := map[string]int{}
+	server := newTestServer(calls)
+	defer server.Close()
+	client := newTestClient(server)
+
+	fcst, err := client.ForecastDetailed("47.6", "-122.3")
+	check(err)
+	assert.Equal(t, server.URL+"/gridpoints/SEW/151,119", fcst.ID)
+	assert.Equal(t, 99, len(fcst.Temperature.Values))
+	assert.Equal(t, "America/Los_Angeles", fcst.Timezone)
+
+	_, err = client.ForecastDetailed("47.6", "-122.3")
+	check(err)
+	assert.Equal(t, 1, calls["/points/47.6,-122.3"])
+	assert.Equal(t, 2, calls["/gridpoints/SEW/151,119"])
+}
+
+func TestClientNotFound(t *testing.T) {
+	server := newTestServer(map[string]int{})
+	defer server.Close()
+	client := newTestClient(server)
+
+	point, err := client.Points("30.5835", "-97.8575")
+	assert.Nil(t, point)
+	assert.True(t, IsNotFound(err))
+	_, ok := client.Cache.Get(server.URL + "/points/30.5835,-97.8575")
+	assert.False(t, ok)
+}
+
+func TestClientContextCanceled(t *testing.T) {
+	calls := map[string]int{}
+	server := newTestServer(calls)
+	defer server.Close()
+	client := newTestClient(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.ForecastDetailedContext(ctx, "47.6", "-122.3")
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, calls["/points/47.6,-122.3"])
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	_, err = client.ForecastDetailedContext(ctx, "47.6", "-122.3")
+	check(err)
+}
+
+func TestClientForecastHourlyPeriods(t *testing.T) {
+	server := newTestServer(map[string]int{})
+	defer server.Close()
+	client := newTestClient(server)
+
+	forecast, err := client.ForecastHourlyPeriods("47.6", "-122.3")
+	check(err)
+	assert.Equal(t, "us", forecast.Units)
+	assert.Equal(t, "SEW", forecast.Point.CWA)
+	assert.Equal(t, 3, len(forecast.Periods))
+	period := forecast.Periods[1]
+	assert.Equal(t, "2019-10-27T10:00:00-07:00", period.StartTime)
+	assert.Equal(t, 37.0, period.Temperature)
+	assert.Equal(t, 2.0, *period.ProbabilityOfPrecipitation.Value)
+	assert.InDelta(t, -7.78, *period.Dewpoint.Value, 0.01)
+	assert.Equal(t, "wmoUnit:degC", period.Dewpoint.UnitCode)
+	// the dewpoint is sent in degC and converted to the forecast units
+	assert.InDelta(t, 18.0, *period.DewpointValue.Value, 0.01)
+	assert.Equal(t, "unit:degF", period.DewpointValue.UnitCode)
+	assert.Equal(t, 41.0, *period.RelativeHumidity.Value)
+	assert.Equal(t, "Sunny", period.Summary)
+	assert.Nil(t, forecast.Periods[2].ProbabilityOfPrecipitation.Value)
+}
+
+func TestClientPointsValidation(t *testing.T) {
+	calls := map[string]int{}
+	server := newTestServer(calls)
+	defer server.Close()
+	client := newTestClient(server)
+
+	// extra decimals are rounded away and share the cache entry
+	point, err := client.PointsFloat(47.60001, -122.30004)
+	check(err)
+	assert.Equal(t, "SEW", point.CWA)
+	_, err = client.Points("47.6000", "-122.3")
+	check(err)
+	assert.Equal(t, 1, calls["/points/47.6,-122.3"])
+
+	_, err = client.Points("", "")
+	assert.True(t, errors.Is(err, ErrInvalidCoordinates))
+	_, err = client.PointsFloat(0, 0)
+	assert.True(t, IsOutOfCoverage(err))
+	_, err = client.Points("48.85660", "2.3522")
+	assert.True(t, IsOutOfCoverage(err))
+	assert.Equal(t, 1, len(calls))
+
+	client.SkipServiceAreaCheck = true
+	_, err = client.Points("48.85660", "2.3522")
+	assert.True(t, IsNotFound(err))
+}
@@ -0,0 +1,229 @@
+package noaa
+
+import (
+	"math"
+	"time"
+)
+
+// Reducer aggregates the hourly values of a day into one value
+type Reducer int
+
+// Reducers for DailySeries
+const (
+	ReduceMin Reducer = iota
+	ReduceMax
+	ReduceSum
+	ReduceMean
+)
+
+func (r Reducer) reduce(values []float64) (float64, bool) {
+	out := 0.0
+	count := 0
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		switch {
+		case count == 0 && r != ReduceSum && r != ReduceMean:
+			out = v
+		case r == ReduceMin:
+			out = math.Min(out, v)
+		case r == ReduceMax:
+			out = math.Max(out, v)
+		default:
+			out += v
+		}
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	if r == ReduceMean {
+		out /= float64(count)
+	}
+	return out, true
+}
+
+// DailySeries describes one series of a ForecastDaily: the grid layer it is
+// computed from (a ForecastHourly series name) and how hours are aggregated
+type DailySeries struct {
+	Name    string
+	Layer   string
+	Reducer Reducer
+}
+
+// DefaultDailySeries are the series built by CreateForecastDaily
+var DefaultDailySeries = []DailySeries{
+	{"TemperatureHigh", "Temperature", ReduceMax},
+	{"TemperatureLow", "Temperature", ReduceMin},
+	{"PrecipitationTotal", "PrecipitationQuantity", ReduceSum},
+	{"SnowFallTotal", "SnowFallAmount", ReduceSum},
+	{"WindSpeedMax", "WindSpeed", ReduceMax},
+	{"WindGustMax", "WindGust", ReduceMax},
+	{"PrecipitationProbabilityMax", "PrecipitationProbability", ReduceMax},
+	{"SkyCoverMean", "SkyCover", ReduceMean},
+}
+
+// DailyExtremes compares the high and low of the hourly temperatures with the
+// maxTemperature / minTemperature layers issued by the forecaster. Values are
+// nil when the day has no data.
+type DailyExtremes struct {
+	High           *float64 `json:"high"`
+	Low            *float64 `json:"low"`
+	MaxTemperature *float64 `json:"maxTemperature"`
+	MinTemperature *float64 `json:"minTemperature"`
+}
+
+// HighDifference is High - MaxTemperature, false when either is missing
+func (e DailyExtremes) HighDifference() (float64, bool) {
+	if e.High == nil || e.MaxTemperature == nil {
+		return 0, false
+	}
+	return *e.High - *e.MaxTemperature, true
+}
+
+// LowDifference is Low - MinTemperature, false when either is missing
+func (e DailyExtremes) LowDifference() (float64, bool) {
+	if e.Low == nil || e.MinTemperature == nil {
+		return 0, false
+	}
+	return *e.Low - *e.MinTemperature, true
+}
+
+// ForecastDaily aggregates a ForecastGridResponse over local calendar days.
+// Days holds local midnights, Hours the number of forecast hours in each day
+// (partial at the edges, 23 or 25 on daylight saving changes). Missing values
+// are NaN.
+type ForecastDaily struct {
+	CreatedAt   time.Time       `json:"createdAt"`
+	Endpoint    string          `json:"endpoint"`
+	Timezone    string          `json:"timezone"`
+	Days        []time.Time     `json:"days"`
+	Hours       []int           `json:"hours"`
+	SeriesNames []string        `json:"seriesNames"`
+	Units       []string        `json:"units"`
+	Values      [][]float64     `json:"values"`
+	Extremes    []DailyExtremes `json:"extremes"`
+}
+
+// CreateForecastDaily builds the DefaultDailySeries of a grid forecast for the days of
+// timezone, the IANA name given by PointsResponse.Timezone. The empty name uses grid.Timezone.
+func CreateForecastDaily(grid *ForecastGridResponse, timezone string) (*ForecastDaily, error) {
+	return CreateForecastDailySeries(grid, timezone, DefaultDailySeries)
+}
+
+// CreateForecastDailySeries is CreateForecastDaily with custom series.
+// Layers missing from the grid give NaN values.
+func CreateForecastDailySeries(grid *ForecastGridResponse, timezone string, series []DailySeries) (*ForecastDaily, error) {
+	if timezone == "" {
+		timezone = grid.Timezone
+	}
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+	validTimes := grid.ValidTimes.Snap(time.Hour)
+	tMin := validTimes.Time
+	tMax := validTimes.endTime().Add(-time.Hour)
+
+	times := make([]time.Time, 0)
+	for t := tMin; !t.After(tMax); t = t.Add(time.Hour) {
+		times = append(times, t)
+	}
+	localDays := groupLocalDays(times, loc)
+	days := make([]time.Time, len(localDays))
+	hours := make([]int, len(localDays))
+	for d, day := range localDays {
+		days[d] = day.Date
+		hours[d] = day.Hours()
+	}
+
+	timeseries := grid.timeseriesMap()
+	values := make([][]float64, len(series))
+	seriesNames := make([]string, len(series))
+	seriesUnits := make([]string, len(series))
+	for i, spec := range series {
+		seriesNames[i] = spec.Name
+		values[i] = make([]float64, len(days))
+		ts, ok := timeseries[spec.Layer]
+		if !ok {
+			for d := range days {
+				values[i][d] = math.NaN()
+			}
+			continue
+		}
+		seriesUnits[i] = unitSymbol(ts.Units)
+		method := DefaultResampleMethod(spec.Layer)
+		if method == ResampleStep {
+			// only aggregate actual data
+			method = ResampleNaNFill
+		}
+		hourly, err := ts.hourlyMethod(tMin, tMax, method)
+		if err != nil {
+			return nil, err
+		}
+		dayValues := make([]float64, len(hourly.Values))
+		for h, v := range hourly.Values {
+			dayValues[h] = v.Value
+		}
+		for d, day := range localDays {
+			value, ok := spec.Reducer.reduce(dayValues[day.Start:day.End])
+			if !ok {
+				value = math.NaN()
+			}
+			// round to one digit precision
+			values[i][d] = math.Round(value*10) / 10
+		}
+	}
+	return &ForecastDaily{
+		CreatedAt:   grid.Updated,
+		Endpoint:    grid.ID,
+		Timezone:    loc.String(),
+		Days:        days,
+		Hours:       hours,
+		SeriesNames: seriesNames,
+		Units:       seriesUnits,
+		Values:      values,
+		Extremes:    dailyExtremes(grid, days, loc, seriesNames, values),
+	}, nil
+}
+
+func dailyExtremes(grid *ForecastGridResponse, days []time.Time, loc *time.Location, seriesNames []string, values [][]float64) []DailyExtremes {
+	extremes := make([]DailyExtremes, len(days))
+	index := make(map[time.Time]int, len(days))
+	for d, day := range days {
+		index[day] = d
+	}
+	for i, name := range seriesNames {
+		for d, v := range values[i] {
+			if math.IsNaN(v) {
+				continue
+			}
+			value := v
+			switch name {
+			case "TemperatureHigh":
+				extremes[d].High = &value
+			case "TemperatureLow":
+				extremes[d].Low = &value
+			}
+		}
+	}
+	// the daytime max belongs to the day it starts, the overnight min to the day it ends
+	if grid.MaxTemperature != nil {
+		for _, v := range grid.MaxTemperature.Values {
+			if d, ok := index[localDay(v.Time.Time, loc)]; ok {
+				value := math.Round(v.Value*10) / 10
+				extremes[d].MaxTemperature = &value
+			}
+		}
+	}
+	if grid.MinTemperature != nil {
+		for _, v := range grid.MinTemperature.Values {
+			if d, ok := index[localDay(v.Time.endTime().Add(-time.Nanosecond), loc)]; ok {
+				value := math.Round(v.Value*10) / 10
+				extremes[d].MinTemperature = &value
+			}
+		}
+	}
+	return extremes
+}
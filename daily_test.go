@@ -0,0 +1,86 @@
+package noaa
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastDailyReducers(t *testing.T) {
+	var grid ForecastGridResponse
+	check(json.Unmarshal([]byte(`{
+		"validTimes": "2019-10-27T20:00:00+00:00/PT36H",
+		"temperature": {"uom": "unit:degC", "values": [
+			{"validTime": "2019-10-27T20:00:00+00:00/PT12H", "value": 10},
+			{"validTime": "2019-10-28T08:00:00+00:00/PT12H", "value": 4},
+			{"validTime": "2019-10-28T20:00:00+00:00/PT12H", "value": 16}
+		]},
+		"quantitativePrecipitation": {"uom": "unit:mm", "values": [
+			{"validTime": "2019-10-28T04:00:00+00:00/PT6H", "value": 6}
+		]},
+		"maxTemperature": {"uom": "unit:degC", "values": [
+			{"validTime": "2019-10-28T15:00:00+00:00/PT13H", "value": 15}
+		]},
+		"minTemperature": {"uom": "unit:degC", "values": [
+			{"validTime": "2019-10-28T03:00:00+00:00/PT14H", "value": 5}
+		]}
+	}`), &grid))
+
+	daily, err := CreateForecastDaily(&grid, "America/Los_Angeles")
+	check(err)
+	assert.Equal(t, "America/Los_Angeles", daily.Timezone)
+	loc, _ := time.LoadLocation("America/Los_Angeles")
+	assert.Equal(t, []time.Time{
+		time.Date(2019, 10, 27, 0, 0, 0, 0, loc),
+		time.Date(2019, 10, 28, 0, 0, 0, 0, loc),
+		time.Date(2019, 10, 29, 0, 0, 0, 0, loc),
+	}, daily.Days)
+	// 13:00-24:00, a full day, then 00:00-01:00 local
+	assert.Equal(t, []int{11, 24, 1}, daily.Hours)
+
+	// interpolated between 10 at 13:00, 4 at 01:00 and 16 at 13:00 local
+	assert.Equal(t, []float64{10, 16, 16}, seriesValues(daily.SeriesNames, daily.Values, "TemperatureHigh"))
+	assert.Equal(t, []float64{5, 4, 16}, seriesValues(daily.SeriesNames, daily.Values, "TemperatureLow"))
+	// 21:00 on the 27th to 03:00 on the 28th local
+	assert.Equal(t, []float64{3, 3, 0}, seriesValues(daily.SeriesNames, daily.Values, "PrecipitationTotal"))
+	assert.Equal(t, "mm", daily.Units[2])
+	assert.True(t, math.IsNaN(seriesValues(daily.SeriesNames, daily.Values, "SkyCoverMean")[0]))
+
+	// the max starts on the 28th local, the min ends on the morning of the 28th
+	extremes := daily.Extremes[1]
+	assert.Equal(t, 15.0, *extremes.MaxTemperature)
+	assert.Equal(t, 5.0, *extremes.MinTemperature)
+	diff, ok := extremes.HighDifference()
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, diff)
+	diff, ok = extremes.LowDifference()
+	assert.True(t, ok)
+	assert.Equal(t, -1.0, diff)
+	_, ok = daily.Extremes[0].HighDifference()
+	assert.False(t, ok)
+
+	_, err = CreateForecastDaily(&grid, "Nowhere/Special")
+	assert.Error(t, err)
+}
+
+func TestForecastDailyDST(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	daily, err := CreateForecastDaily(fcst, "America/Los_Angeles")
+	check(err)
+	assert.Equal(t, 9, len(daily.Days))
+	// daylight saving time ends on November 3rd
+	assert.Equal(t, []int{15, 24, 24, 24, 24, 24, 24, 25, 14}, daily.Hours)
+	for i, name := range daily.SeriesNames {
+		for d, v := range daily.Values[i] {
+			assert.False(t, math.IsNaN(v), "%s day %d", name, d)
+		}
+	}
+	for i, extremes := range daily.Extremes[1 : len(daily.Extremes)-1] {
+		assert.NotNil(t, extremes.MaxTemperature, "day %d", i+1)
+		assert.NotNil(t, extremes.MinTemperature, "day %d", i+1)
+	}
+}
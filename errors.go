@@ -0,0 +1,98 @@
+package noaa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Errors returned before any request is made for invalid coordinates
+var (
+	ErrInvalidCoordinates = errors.New("invalid coordinates")
+	ErrOutOfCoverage      = errors.New("point is outside of the NWS coverage")
+)
+
+// Problem types returned by api.weather.gov, see APIError.ProblemType
+const (
+	ProblemInvalidPoint      = "InvalidPoint"
+	ProblemUnexpectedProblem = "UnexpectedProblem"
+)
+
+// APIError holds the application/problem+json body of a failed request
+type APIError struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Status        int    `json:"status"`
+	Detail        string `json:"detail"`
+	CorrelationID string `json:"correlationId"`
+	Instance      string `json:"instance"`
+	// Endpoint is the URL that was requested
+	Endpoint string `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	if msg == "" {
+		msg = "data not found"
+	}
+	return fmt.Sprintf("%d: %s -> %s", e.Status, msg, e.Endpoint)
+}
+
+// ProblemType is the last path element of Type, e.g. "InvalidPoint"
+func (e *APIError) ProblemType() string {
+	return e.Type[strings.LastIndex(e.Type, "/")+1:]
+}
+
+// newAPIError decodes the problem from the body of a failed response.
+// Bodies that are not JSON still produce an error with the HTTP status.
+func newAPIError(res *http.Response, endpoint string) *APIError {
+	apiErr := &APIError{}
+	buf, err := ioutil.ReadAll(io.LimitReader(res.Body, 1<<16))
+	if err == nil {
+		json.Unmarshal(buf, apiErr)
+	}
+	if apiErr.Status == 0 {
+		apiErr.Status = res.StatusCode
+	}
+	if apiErr.Title == "" {
+		apiErr.Title = http.StatusText(res.StatusCode)
+	}
+	apiErr.Endpoint = endpoint
+	return apiErr
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	ok := errors.As(err, &apiErr)
+	return apiErr, ok
+}
+
+// IsNotFound reports whether err is an APIError with status 404
+func IsNotFound(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.Status == http.StatusNotFound
+}
+
+// IsOutOfCoverage reports whether err says the requested point is
+// outside of the area covered by the National Weather Service
+func IsOutOfCoverage(err error) bool {
+	if errors.Is(err, ErrOutOfCoverage) {
+		return true
+	}
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.ProblemType() == ProblemInvalidPoint
+}
+
+// IsUnexpectedProblem reports whether err is an internal failure of the API,
+// which happens regularly for gridpoint endpoints and usually passes
+func IsUnexpectedProblem(err error) bool {
+	apiErr, ok := asAPIError(err)
+	return ok && apiErr.ProblemType() == ProblemUnexpectedProblem
+}
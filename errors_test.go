@@ -0,0 +1,72 @@
+package noaa
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newProblemServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestAPIErrorOutOfCoverage(t *testing.T) {
+	server := newProblemServer(http.StatusNotFound, `{
+		"correlationId": "1ab2c3",
+		"title": "Invalid Point",
+		"type": "https://api.weather.gov/problems/InvalidPoint",
+		"status": 404,
+		"detail": "Unable to provide data for requested point 48.8566,2.3522",
+		"instance": "https://api.weather.gov/requests/1ab2c3"
+	}`)
+	defer server.Close()
+
+	client := newTestClient(server)
+	client.SkipServiceAreaCheck = true
+	_, err := client.Points("48.8566", "2.3522")
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "1ab2c3", apiErr.CorrelationID)
+	assert.Equal(t, "https://api.weather.gov/requests/1ab2c3", apiErr.Instance)
+	assert.Equal(t, server.URL+"/points/48.8566,2.3522", apiErr.Endpoint)
+	assert.True(t, IsNotFound(err))
+	assert.True(t, IsOutOfCoverage(err))
+	assert.False(t, IsUnexpectedProblem(err))
+	assert.Contains(t, err.Error(), "Unable to provide data")
+}
+
+func TestAPIErrorUnexpectedProblem(t *testing.T) {
+	server := newProblemServer(http.StatusInternalServerError, `{
+		"title": "Unexpected Problem",
+		"type": "https://api.weather.gov/problems/UnexpectedProblem",
+		"status": 500,
+		"detail": "An unexpected problem has occurred."
+	}`)
+	defer server.Close()
+	client := newRetryClient(server, 2)
+
+	_, err := client.GetEndpointGridForecast(server.URL + "/gridpoints/SEW/151,119")
+	var retryErr *RetryError
+	assert.True(t, errors.As(err, &retryErr))
+	assert.True(t, IsUnexpectedProblem(err))
+	assert.False(t, IsNotFound(err))
+}
+
+func TestAPIErrorPlainBody(t *testing.T) {
+	server := newProblemServer(http.StatusBadRequest, `bad request`)
+	defer server.Close()
+
+	_, err := newTestClient(server).Points("47.6", "-122.3")
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.Status)
+	assert.Equal(t, "Bad Request", apiErr.Title)
+	assert.False(t, IsOutOfCoverage(err))
+}
@@ -2,10 +2,11 @@ package noaa
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/adamgreenhall/noaa/units"
 )
 
 // ForecastTime parses the NWS time format
@@ -44,67 +45,98 @@ func (ts *ForecastTimeseries) fillInfo(name string, id string) *ForecastTimeseri
 	return ts
 }
 
-func (t *ForecastTime) endTime() time.Time {
-	return t.Time.Add(t.Duration)
+// snapped returns a copy of the timeseries with every interval snapped to step, see ForecastTime.Snap
+func (ts *ForecastTimeseries) snapped(step time.Duration) *ForecastTimeseries {
+	out := &ForecastTimeseries{
+		Name:   ts.Name,
+		ID:     ts.ID,
+		Units:  ts.Units,
+		Values: make([]*ForecastTimeseriesValue, len(ts.Values)),
+	}
+	for i, v := range ts.Values {
+		out.Values[i] = &ForecastTimeseriesValue{Time: v.Time.Snap(step), Value: v.Value}
+	}
+	return out
 }
 
-func parseDuration(t string) (*time.Duration, error) {
-	durationRegex := regexp.MustCompile(`([0-9]d)?t?([0-9]+h)?([0-9]+m)?`)
-	if !strings.Contains(t, "P") {
-		return nil, fmt.Errorf("no duration suffix found for time %s", t)
+// Convert returns a copy of the timeseries in the unit given by code, e.g. "unit:degF"
+func (ts *ForecastTimeseries) Convert(code string) (*ForecastTimeseries, error) {
+	to, err := units.Parse(code)
+	if err != nil {
+		return nil, err
 	}
-	durStr := strings.ToLower(strings.Split(t, "P")[1])
-	matches := durationRegex.FindStringSubmatch(durStr)
-	if len(matches) == 0 {
-		return nil, fmt.Errorf("duration pattern does not match expected: %s", t)
+	return ts.convert(to)
+}
+
+// ToSystem returns a copy of the timeseries in the metric or imperial counterpart of its unit
+func (ts *ForecastTimeseries) ToSystem(system units.System) (*ForecastTimeseries, error) {
+	from, err := units.Parse(ts.Units)
+	if err != nil {
+		return nil, err
 	}
-	dur := time.Duration(0)
-	if len(matches[1]) > 0 {
-		durIntDays, err := strconv.Atoi(strings.ReplaceAll(matches[1], "d", ""))
-		if err != nil {
-			return nil, err
-		}
-		durDays, err := time.ParseDuration(fmt.Sprintf("%dh", durIntDays*24))
-		if err != nil {
-			return nil, err
-		}
-		dur += durDays
+	return ts.convert(from.In(system))
+}
+
+func (ts *ForecastTimeseries) convert(to units.Unit) (*ForecastTimeseries, error) {
+	from, err := units.Parse(ts.Units)
+	if err != nil {
+		return nil, err
 	}
-	if len(matches[2]) > 0 {
-		durHours, err := time.ParseDuration(strings.ReplaceAll(matches[2], "t", ""))
-		if err != nil {
-			return nil, err
-		}
-		dur += durHours
+	out := &ForecastTimeseries{
+		Name:   ts.Name,
+		ID:     ts.ID,
+		Units:  ts.Units,
+		Values: make([]*ForecastTimeseriesValue, len(ts.Values)),
 	}
-	if len(matches[3]) > 0 {
-		durMinutes, err := time.ParseDuration(matches[3])
+	if from.Code != to.Code {
+		out.Units = to.String()
+	}
+	for i, v := range ts.Values {
+		value, err := units.Convert(v.Value, from, to)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%s for %s at %s", err.Error(), ts.Name, ts.ID)
 		}
-		// round up to the next hour
-		if durMinutes >= 1*time.Minute {
-			dur += time.Hour
+		out.Values[i] = &ForecastTimeseriesValue{Time: v.Time, Value: value}
+	}
+	return out, nil
+}
+
+func (t *ForecastTime) endTime() time.Time {
+	return t.Time.Add(t.Duration)
+}
+
+// intervalIndices returns for each time the index of the interval containing it, or -1.
+// intervals must be sorted by start time and not overlap.
+func intervalIndices(times []time.Time, intervals []ForecastTime) []int {
+	out := make([]int, len(times))
+	for i, t := range times {
+		// first interval starting after t
+		idx := sort.Search(len(intervals), func(j int) bool { return intervals[j].Time.After(t) })
+		out[i] = -1
+		if idx > 0 && t.Before(intervals[idx-1].endTime()) {
+			out[i] = idx - 1
 		}
 	}
-	return &dur, nil
+	return out
 }
 
-// UnmarshalJSON parses the NWS time format
+// UnmarshalJSON parses the NWS time format, an ISO 8601 interval
 func (t *ForecastTime) UnmarshalJSON(buf []byte) error {
-	ttStr := strings.ReplaceAll(string(buf), `"`, "")
-	tBase := strings.Split(ttStr, "+")[0]
-	// truncate to hour
-	tBase = strings.Split(tBase, ":")[0] + ":00:00Z"
-	tt, err := time.Parse(time.RFC3339, tBase)
-	if err != nil {
-		return err
-	}
-	dur, err := parseDuration(ttStr)
+	ft, err := ParseInterval(strings.ReplaceAll(string(buf), `"`, ""))
 	if err != nil {
 		return err
 	}
-	t.Time = tt
-	t.Duration = *dur
+	*t = ft
 	return nil
 }
+
+// Snap widens the interval to whole steps: the start is truncated
+// and the end rounded up to a multiple of step (e.g. time.Hour)
+func (t ForecastTime) Snap(step time.Duration) ForecastTime {
+	start := t.Time.Truncate(step)
+	end := t.endTime()
+	if rounded := end.Truncate(step); rounded.Before(end) {
+		end = rounded.Add(step)
+	}
+	return ForecastTime{Time: start, Duration: end.Sub(start)}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/adamgreenhall/noaa/units"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,38 +17,54 @@ func TestParseDuration(t *testing.T) {
 		"P1DT2H",
 		"PT2H59M40S",
 		"P5DT10H14M34S",
+		"P1W",
+		"PT0.5H",
+		"PT1,5S",
 	}
 	var durationTimes = [...]time.Duration{
 		time.Hour * 3,
 		time.Hour * 24,
 		time.Hour * 26,
-		time.Hour * 3,
-		time.Hour * (5*24 + 11),
+		time.Hour*2 + time.Minute*59 + time.Second*40,
+		time.Hour*(5*24+10) + time.Minute*14 + time.Second*34,
+		time.Hour * 24 * 7,
+		time.Minute * 30,
+		time.Millisecond * 1500,
 	}
 	for i, dur := range durations {
-		td, err := parseDuration(dur)
+		td, err := ParseISODuration(dur)
 		check(err)
-		assert.Equal(t, *td, durationTimes[i])
+		assert.Equal(t, durationTimes[i], td.Duration())
+	}
+	for _, dur := range []string{"", "P", "PT", "3H", "P1H", "PT1D", "P-1D"} {
+		_, err := ParseISODuration(dur)
+		assert.Error(t, err, dur)
 	}
 }
 
 func TestParseTime(t *testing.T) {
-	// truncate to hour
 	var timeStrings = [...]string{
 		"2020-08-19T04:00:00+00:00/PT5H",
 		"2020-08-19T09:43:26+00:00/PT6H16M34S",
 		"2020-08-25T00:00:48+00:00/PT59M12S",
 	}
-	var times = make([]time.Time, len(timeStrings))
-	ts, err := time.Parse(time.RFC3339, "2020-08-19T04:00:00Z")
-	times[0] = ts
-	check(err)
-	ts, err = time.Parse(time.RFC3339, "2020-08-19T09:00:00Z")
-	times[1] = ts
-	ts, err = time.Parse(time.RFC3339, "2020-08-25T00:00:00Z")
-	check(err)
-	times[2] = ts
+	var times = [...]time.Time{
+		time.Date(2020, 8, 19, 4, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 19, 9, 43, 26, 0, time.UTC),
+		time.Date(2020, 8, 25, 0, 0, 48, 0, time.UTC),
+	}
 	var durations = [...]time.Duration{
+		time.Hour * 5,
+		time.Hour*6 + time.Minute*16 + time.Second*34,
+		time.Minute*59 + time.Second*12,
+	}
+	// snapping to the hour truncates the start and rounds up the end
+	var snappedTimes = [...]time.Time{
+		time.Date(2020, 8, 19, 4, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 19, 9, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 25, 0, 0, 0, 0, time.UTC),
+	}
+	var snappedDurations = [...]time.Duration{
 		time.Hour * 5,
 		time.Hour * 7,
 		time.Hour * 1,
@@ -55,7 +72,79 @@ func TestParseTime(t *testing.T) {
 	for i, ts := range timeStrings {
 		var ft ForecastTimeseriesValue
 		check(json.Unmarshal([]byte(fmt.Sprintf(`{"validTime": "%s"}`, ts)), &ft))
-		assert.Equal(t, ft.Time.Time, times[i])
-		assert.Equal(t, ft.Time.Duration, durations[i])
+		assert.Equal(t, times[i], ft.Time.Time)
+		assert.Equal(t, durations[i], ft.Time.Duration)
+		snapped := ft.Time.Snap(time.Hour)
+		assert.Equal(t, snappedTimes[i], snapped.Time)
+		assert.Equal(t, snappedDurations[i], snapped.Duration)
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	start := time.Date(2020, 3, 1, 11, 0, 0, 0, time.UTC)
+	testCases := map[string]ForecastTime{
+		"2020-03-01T04:00:00-07:00/PT5H":            {start, 5 * time.Hour},
+		"2020-03-01T11:00:00Z/2020-03-01T16:30:00Z": {start, 5*time.Hour + 30*time.Minute},
+		"PT2H/2020-03-01T13:00:00+00:00":            {start, 2 * time.Hour},
+		"2020-03-01T16:30:00+05:30/P1W":             {start, 7 * 24 * time.Hour},
+		"2020-03-01T11:00:00Z/P1M":                  {start, 31 * 24 * time.Hour},
+		"2020-03-01T11:00:00.000+00:00/P1DT0.25H":   {start, 24*time.Hour + 15*time.Minute},
+		"2020-03-01T11:00:00+0000/PT1H":             {start, time.Hour},
+	}
+	for s, expected := range testCases {
+		ft, err := ParseInterval(s)
+		check(err)
+		assert.Equal(t, expected, ft, s)
+	}
+	for _, s := range []string{"2020-03-01T11:00:00Z", "2020-03-01T11:00:00Z/PT1H/PT1H", "2020-03-01T11:00:00Z/2020-03-01T10:00:00Z", "P1D/P1D"} {
+		_, err := ParseInterval(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestTimeseriesConvert(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+
+	tsF, err := fcst.Temperature.Convert("unit:degF")
+	check(err)
+	assert.Equal(t, "unit:degF", tsF.Units)
+	assert.InDelta(t, 15.0, tsF.Values[0].Value, 1e-9)
+	assert.Equal(t, "unit:degC", fcst.Temperature.Units)
+
+	wind, err := fcst.WindSpeed.ToSystem(units.Imperial)
+	check(err)
+	assert.Equal(t, "unit:mi_h-1", wind.Units)
+	assert.InDelta(t, 4.603, wind.Values[0].Value, 1e-3)
+
+	_, err = fcst.Temperature.Convert("unit:m")
+	assert.Error(t, err)
+}
+
+func TestHourlyToSystem(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	hourly, err := CreateForecastHourly(fcst)
+	check(err)
+	assert.Equal(t, "C", hourly.Units[0])
+	assert.Equal(t, int64(3069), hourly.ElevationMeters)
+
+	imperial, err := hourly.ToSystem(units.Imperial)
+	check(err)
+	assert.Equal(t, "F", imperial.Units[0])
+	assert.InDelta(t, 15.0, imperial.Values[0][0], 0.1)
+	assert.Equal(t, "C", hourly.Units[0])
+
+	metric, err := imperial.ToSystem(units.Metric)
+	check(err)
+	assert.Equal(t, "C", metric.Units[0])
+	assert.InDelta(t, hourly.Values[0][0], metric.Values[0][0], 0.1)
+
+	custom, err := hourly.Convert(map[string]string{"WindSpeed": "unit:km_h-1"})
+	check(err)
+	for i, name := range custom.SeriesNames {
+		if name == "WindSpeed" {
+			assert.Equal(t, "km/h", custom.Units[i])
+		}
 	}
 }
@@ -0,0 +1,263 @@
+package noaa
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadius is the mean radius of the earth in meters
+const earthRadius = 6371008.8
+
+// LatLon is a position in decimal degrees
+type LatLon struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// DistanceTo is the great circle distance to other in meters
+func (p LatLon) DistanceTo(other LatLon) float64 {
+	lat1, lat2 := radians(p.Lat), radians(other.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(other.Lon - p.Lon)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BearingTo is the initial bearing to other in degrees clockwise from north, in [0, 360)
+func (p LatLon) BearingTo(other LatLon) float64 {
+	lat1, lat2 := radians(p.Lat), radians(other.Lat)
+	dLon := radians(other.Lon - p.Lon)
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	bearing := math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+	return bearing
+}
+
+// String is the canonical "lat,lon" form used by the API, rounded to four decimals.
+// More decimals make api.weather.gov answer with a redirect.
+func (p LatLon) String() string {
+	return formatCoordinate(p.Lat) + "," + formatCoordinate(p.Lon)
+}
+
+func formatCoordinate(v float64) string {
+	rounded := math.Round(v*1e4) / 1e4
+	if rounded == 0 {
+		// avoid "-0"
+		rounded = 0
+	}
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// Validate checks that the latitude and longitude are within range
+func (p LatLon) Validate() error {
+	if math.IsNaN(p.Lat) || math.IsNaN(p.Lon) {
+		return fmt.Errorf("%w: NaN in %v,%v", ErrInvalidCoordinates, p.Lat, p.Lon)
+	}
+	if p.Lat < -90 || p.Lat > 90 {
+		return fmt.Errorf("%w: latitude %v is not within [-90, 90]", ErrInvalidCoordinates, p.Lat)
+	}
+	if p.Lon < -180 || p.Lon > 180 {
+		return fmt.Errorf("%w: longitude %v is not within [-180, 180]", ErrInvalidCoordinates, p.Lon)
+	}
+	return nil
+}
+
+// ServiceArea is a bounding box of an area covered by the National Weather Service
+type ServiceArea struct {
+	Name string
+	Min  LatLon
+	Max  LatLon
+}
+
+// Contains is true when p is within the box
+func (a ServiceArea) Contains(p LatLon) bool {
+	return p.Lat >= a.Min.Lat && p.Lat <= a.Max.Lat && p.Lon >= a.Min.Lon && p.Lon <= a.Max.Lon
+}
+
+// ServiceAreas are generous boxes around the land and coastal waters with NWS forecasts.
+// Points inside may still be refused by the API, points outside are refused without a request.
+var ServiceAreas = []ServiceArea{
+	{"CONUS", LatLon{24.0, -125.5}, LatLon{49.5, -66.5}},
+	{"Alaska", LatLon{51.0, -180.0}, LatLon{71.5, -129.5}},
+	{"Aleutians", LatLon{51.0, 172.0}, LatLon{53.5, 180.0}},
+	{"Hawaii", LatLon{18.5, -161.0}, LatLon{22.5, -154.5}},
+	{"Puerto Rico and Virgin Islands", LatLon{17.5, -68.0}, LatLon{18.75, -64.25}},
+	{"Guam and Northern Mariana Islands", LatLon{13.0, 144.5}, LatLon{20.75, 146.25}},
+	{"American Samoa", LatLon{-14.75, -171.25}, LatLon{-10.75, -168.0}},
+	{"Pacific coastal waters", LatLon{30.0, -130.0}, LatLon{49.5, -116.0}},
+	{"Atlantic coastal waters", LatLon{23.0, -82.0}, LatLon{45.5, -65.0}},
+	{"Gulf of Mexico", LatLon{23.0, -98.0}, LatLon{31.0, -80.0}},
+	{"Alaska coastal waters", LatLon{50.0, -180.0}, LatLon{72.5, -129.5}},
+	{"Hawaii coastal waters", LatLon{17.5, -162.0}, LatLon{23.5, -153.5}},
+}
+
+// ServiceArea returns the name of the first of ServiceAreas containing p, false when there is none
+func (p LatLon) ServiceArea() (string, bool) {
+	for _, area := range ServiceAreas {
+		if area.Contains(p) {
+			return area.Name, true
+		}
+	}
+	return "", false
+}
+
+// ParseLatLon parses and validates a latitude and longitude given as strings
+func ParseLatLon(lat string, lon string) (LatLon, error) {
+	p, err := parseLatLon(lat, lon)
+	if err != nil {
+		return LatLon{}, fmt.Errorf("%w: %s", ErrInvalidCoordinates, err.Error())
+	}
+	if err := p.Validate(); err != nil {
+		return LatLon{}, err
+	}
+	return p, nil
+}
+
+// parseLatLon parses the lat, lon strings used by the API calls
+func parseLatLon(lat string, lon string) (LatLon, error) {
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return LatLon{}, fmt.Errorf("invalid latitude %q", lat)
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	if err != nil {
+		return LatLon{}, fmt.Errorf("invalid longitude %q", lon)
+	}
+	return LatLon{Lat: latitude, Lon: longitude}, nil
+}
+
+// parseWKTPoint parses a well-known text point, "POINT(lon lat)"
+func parseWKTPoint(s string) (LatLon, error) {
+	body := strings.TrimSpace(s)
+	if !strings.HasPrefix(strings.ToUpper(body), "POINT") {
+		return LatLon{}, fmt.Errorf("invalid WKT point %q", s)
+	}
+	body = strings.TrimSpace(body[len("POINT"):])
+	if !strings.HasPrefix(body, "(") || !strings.HasSuffix(body, ")") {
+		return LatLon{}, fmt.Errorf("invalid WKT point %q", s)
+	}
+	coords := strings.Fields(body[1 : len(body)-1])
+	if len(coords) != 2 {
+		return LatLon{}, fmt.Errorf("invalid WKT point %q", s)
+	}
+	point, err := parseLatLon(coords[1], coords[0])
+	if err != nil {
+		return LatLon{}, fmt.Errorf("invalid WKT point %q: %s", s, err.Error())
+	}
+	return point, nil
+}
+
+// Polygon is a list of closed rings, the first is the outer boundary and any others are holes
+type Polygon [][]LatLon
+
+// Contains is true when p is inside the outer ring and outside the holes
+func (poly Polygon) Contains(p LatLon) bool {
+	if len(poly) == 0 || !ringContains(poly[0], p) {
+		return false
+	}
+	for _, hole := range poly[1:] {
+		if ringContains(hole, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains casts a ray from p towards increasing longitude and counts the crossings
+func ringContains(ring []LatLon, p LatLon) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lon < (b.Lon-a.Lon)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// parseWKTPolygons parses a well-known text POLYGON or MULTIPOLYGON
+func parseWKTPolygons(s string) ([]Polygon, error) {
+	body := strings.TrimSpace(s)
+	upper := strings.ToUpper(body)
+	var depth int
+	switch {
+	case strings.HasPrefix(upper, "MULTIPOLYGON"):
+		body, depth = body[len("MULTIPOLYGON"):], 3
+	case strings.HasPrefix(upper, "POLYGON"):
+		body, depth = body[len("POLYGON"):], 2
+	default:
+		return nil, fmt.Errorf("invalid WKT polygon %q", s)
+	}
+	groups, rest, err := parseWKTGroup(strings.TrimSpace(body), depth)
+	if err != nil || strings.TrimSpace(rest) != "" {
+		return nil, fmt.Errorf("invalid WKT polygon %q", s)
+	}
+	if depth == 2 {
+		groups = []interface{}{groups}
+	}
+	polygons := make([]Polygon, 0, len(groups))
+	for _, group := range groups {
+		polygon := Polygon{}
+		for _, ring := range group.([]interface{}) {
+			polygon = append(polygon, ring.([]LatLon))
+		}
+		polygons = append(polygons, polygon)
+	}
+	return polygons, nil
+}
+
+// parseWKTGroup parses depth levels of parenthesized lists, the innermost
+// holding "lon lat" pairs, and returns what follows the closing parenthesis
+func parseWKTGroup(s string, depth int) ([]interface{}, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return nil, s, fmt.Errorf("expected ( in %q", s)
+	}
+	if depth == 1 {
+		end := strings.Index(s, ")")
+		if end < 0 {
+			return nil, s, fmt.Errorf("expected ) in %q", s)
+		}
+		ring := []LatLon{}
+		for _, pair := range strings.Split(s[1:end], ",") {
+			coords := strings.Fields(pair)
+			if len(coords) != 2 {
+				return nil, s, fmt.Errorf("invalid coordinates %q", pair)
+			}
+			point, err := parseLatLon(coords[1], coords[0])
+			if err != nil {
+				return nil, s, err
+			}
+			ring = append(ring, point)
+		}
+		return []interface{}{ring}, s[end+1:], nil
+	}
+	out := []interface{}{}
+	rest := s[1:]
+	for {
+		group, next, err := parseWKTGroup(strings.TrimSpace(rest), depth-1)
+		if err != nil {
+			return nil, s, err
+		}
+		if depth == 2 {
+			out = append(out, group[0])
+		} else {
+			out = append(out, group)
+		}
+		rest = strings.TrimSpace(next)
+		switch {
+		case strings.HasPrefix(rest, ","):
+			rest = rest[1:]
+		case strings.HasPrefix(rest, ")"):
+			return out, rest[1:], nil
+		default:
+			return nil, s, fmt.Errorf("expected , or ) in %q", rest)
+		}
+	}
+}
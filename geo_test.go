@@ -0,0 +1,95 @@
+package noaa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatLonDistance(t *testing.T) {
+	seattle := LatLon{Lat: 47.6062, Lon: -122.3321}
+	portland := LatLon{Lat: 45.5152, Lon: -122.6784}
+	assert.InDelta(t, 234000, seattle.DistanceTo(portland), 1000)
+	assert.InDelta(t, seattle.DistanceTo(portland), portland.DistanceTo(seattle), 1e-6)
+	assert.Equal(t, 0.0, seattle.DistanceTo(seattle))
+
+	origin := LatLon{}
+	assert.InDelta(t, 0, origin.BearingTo(LatLon{Lat: 1}), 1e-9)
+	assert.InDelta(t, 90, origin.BearingTo(LatLon{Lon: 1}), 1e-9)
+	assert.InDelta(t, 180, origin.BearingTo(LatLon{Lat: -1}), 1e-9)
+	assert.InDelta(t, 270, origin.BearingTo(LatLon{Lon: -1}), 1e-9)
+}
+
+func TestParseWKTPoint(t *testing.T) {
+	point, err := parseWKTPoint("POINT(-122.31442 47.44467)")
+	check(err)
+	assert.Equal(t, LatLon{Lat: 47.44467, Lon: -122.31442}, point)
+	point, err = parseWKTPoint("Point ( -97.8 30.5 )")
+	check(err)
+	assert.Equal(t, LatLon{Lat: 30.5, Lon: -97.8}, point)
+
+	for _, s := range []string{"", "POINT(1)", "POINT(a b)", "LINESTRING(1 2, 3 4)", "POINT 1 2"} {
+		_, err := parseWKTPoint(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseWKTPolygons(t *testing.T) {
+	polygons, err := parseWKTPolygons("POLYGON((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 6 4, 6 6, 4 6, 4 4))")
+	check(err)
+	assert.Equal(t, 1, len(polygons))
+	assert.Equal(t, 2, len(polygons[0]))
+	assert.Equal(t, LatLon{Lat: 10, Lon: 10}, polygons[0][0][2])
+	assert.True(t, polygons[0].Contains(LatLon{Lat: 2, Lon: 2}))
+	assert.False(t, polygons[0].Contains(LatLon{Lat: 5, Lon: 5}))
+	assert.False(t, polygons[0].Contains(LatLon{Lat: 11, Lon: 5}))
+
+	polygons, err = parseWKTPolygons("MULTIPOLYGON(((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))")
+	check(err)
+	assert.Equal(t, 2, len(polygons))
+	assert.Equal(t, LatLon{Lat: 5, Lon: 6}, polygons[1][0][1])
+
+	for _, s := range []string{"", "POINT(1 2)", "POLYGON(0 0, 1 1)", "POLYGON((0 0, 1))", "POLYGON((0 0, 1 1)"} {
+		_, err := parseWKTPolygons(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestLatLonString(t *testing.T) {
+	assert.Equal(t, "47.6062,-122.3321", LatLon{Lat: 47.60621, Lon: -122.332069}.String())
+	assert.Equal(t, "47.6,-122.3", LatLon{Lat: 47.6, Lon: -122.3}.String())
+	assert.Equal(t, "0,0", LatLon{Lat: -0.00001, Lon: 0}.String())
+}
+
+func TestParseLatLon(t *testing.T) {
+	p, err := ParseLatLon(" 64.828421", "-147.7390417")
+	check(err)
+	assert.Equal(t, LatLon{Lat: 64.828421, Lon: -147.7390417}, p)
+
+	for _, c := range [][2]string{{"", ""}, {"", "-147.7"}, {"64.8", ""}, {"91", "0"}, {"0", "-180.5"}, {"NaN", "0"}, {"north", "west"}} {
+		_, err := ParseLatLon(c[0], c[1])
+		assert.True(t, errors.Is(err, ErrInvalidCoordinates), "%v", c)
+	}
+}
+
+func TestServiceArea(t *testing.T) {
+	cases := map[string]LatLon{
+		"CONUS":                             {Lat: 30.5835, Lon: -97.8575},
+		"Alaska":                            {Lat: 64.828421, Lon: -147.7390417},
+		"Aleutians":                         {Lat: 52.9, Lon: 173.2},
+		"Hawaii":                            {Lat: 21.3069, Lon: -157.8583},
+		"Puerto Rico and Virgin Islands":    {Lat: 18.4655, Lon: -66.1057},
+		"Guam and Northern Mariana Islands": {Lat: 13.4443, Lon: 144.7937},
+		"American Samoa":                    {Lat: -14.2756, Lon: -170.702},
+	}
+	for name, p := range cases {
+		area, ok := p.ServiceArea()
+		assert.True(t, ok, name)
+		assert.Equal(t, name, area)
+	}
+	for _, p := range []LatLon{{}, {Lat: 48.8566, Lon: 2.3522}, {Lat: 51.5074, Lon: -0.1278}, {Lat: 19.4326, Lon: -99.1332}} {
+		_, ok := p.ServiceArea()
+		assert.False(t, ok, "%v", p)
+	}
+}
@@ -0,0 +1,260 @@
+package noaa
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+type forecastElevation struct {
+	Value float64 `json:"value"`
+	Units string  `json:"unitCode"`
+}
+
+// ForecastGridResponse holds the JSON values from /gridpoints/<cwa>/<x,y>
+type ForecastGridResponse struct {
+	ID         string            `json:"@id"`
+	Updated    time.Time         `json:"updateTime"`
+	ValidTimes *ForecastTime     `json:"validTimes"`
+	Elevation  forecastElevation `json:"elevation"`
+	// Timezone is the IANA timezone of the point, set by ForecastDetailed
+	Timezone string `json:"timeZone,omitempty"`
+
+	Temperature                      *ForecastTimeseries `json:"temperature"`
+	Dewpoint                         *ForecastTimeseries `json:"dewpoint"`
+	MaxTemperature                   *ForecastTimeseries `json:"maxTemperature"`
+	MinTemperature                   *ForecastTimeseries `json:"minTemperature"`
+	RelativeHumidity                 *ForecastTimeseries `json:"relativeHumidity"`
+	ApparentTemperature              *ForecastTimeseries `json:"apparentTemperature"`
+	HeatIndex                        *ForecastTimeseries `json:"heatIndex"`
+	WindChill                        *ForecastTimeseries `json:"windChill"`
+	SkyCover                         *ForecastTimeseries `json:"skyCover"`
+	WindDirection                    *ForecastTimeseries `json:"windDirection"`
+	WindSpeed                        *ForecastTimeseries `json:"windSpeed"`
+	WindGust                         *ForecastTimeseries `json:"windGust"`
+	PrecipitationProbability         *ForecastTimeseries `json:"probabilityOfPrecipitation"`
+	PrecipitationQuantity            *ForecastTimeseries `json:"quantitativePrecipitation"`
+	IceAccumulation                  *ForecastTimeseries `json:"iceAccumulation"`
+	SnowFallAmount                   *ForecastTimeseries `json:"snowfallAmount"`
+	SnowLevel                        *ForecastTimeseries `json:"snowLevel"`
+	CeilingHeight                    *ForecastTimeseries `json:"ceilingHeight"`
+	Visibility                       *ForecastTimeseries `json:"visibility"`
+	TransportWindSpeed               *ForecastTimeseries `json:"transportWindSpeed"`
+	TransportWindDirection           *ForecastTimeseries `json:"transportWindDirection"`
+	MixingHeight                     *ForecastTimeseries `json:"mixingHeight"`
+	HainesIndex                      *ForecastTimeseries `json:"hainesIndex"`
+	LightningActivityLevel           *ForecastTimeseries `json:"lightningActivityLevel"`
+	TwentyFootWindSpeed              *ForecastTimeseries `json:"twentyFootWindSpeed"`
+	TwentyFootWindDirection          *ForecastTimeseries `json:"twentyFootWindDirection"`
+	WaveHeight                       *ForecastTimeseries `json:"waveHeight"`
+	WavePeriod                       *ForecastTimeseries `json:"wavePeriod"`
+	WaveDirection                    *ForecastTimeseries `json:"waveDirection"`
+	PrimarySwellHeight               *ForecastTimeseries `json:"primarySwellHeight"`
+	PrimarySwellDirection            *ForecastTimeseries `json:"primarySwellDirection"`
+	SecondarySwellHeight             *ForecastTimeseries `json:"secondarySwellHeight"`
+	SecondarySwellDirection          *ForecastTimeseries `json:"secondarySwellDirection"`
+	WavePeriod2                      *ForecastTimeseries `json:"wavePeriod2"`
+	WindWaveHeight                   *ForecastTimeseries `json:"windWaveHeight"`
+	DispersionIndex                  *ForecastTimeseries `json:"dispersionIndex"`
+	Pressure                         *ForecastTimeseries `json:"pressure"`
+	TropicalStormWindsProbability    *ForecastTimeseries `json:"probabilityOfTropicalStormWinds"`
+	HurricaneWindsProbability        *ForecastTimeseries `json:"probabilityOfHurricaneWinds"`
+	PotentialOf15mphWinds            *ForecastTimeseries `json:"potentialOf15mphWinds"`
+	PotentialOf25mphWinds            *ForecastTimeseries `json:"potentialOf25mphWinds"`
+	PotentialOf35mphWinds            *ForecastTimeseries `json:"potentialOf35mphWinds"`
+	PotentialOf45mphWinds            *ForecastTimeseries `json:"potentialOf45mphWinds"`
+	PotentialOf20mphWindGusts        *ForecastTimeseries `json:"potentialOf20mphWindGusts"`
+	PotentialOf30mphWindGusts        *ForecastTimeseries `json:"potentialOf30mphWindGusts"`
+	PotentialOf40mphWindGusts        *ForecastTimeseries `json:"potentialOf40mphWindGusts"`
+	PotentialOf50mphWindGusts        *ForecastTimeseries `json:"potentialOf50mphWindGusts"`
+	PotentialOf60mphWindGusts        *ForecastTimeseries `json:"potentialOf60mphWindGusts"`
+	GrasslandFireDangerIndex         *ForecastTimeseries `json:"grasslandFireDangerIndex"`
+	ThunderProbability               *ForecastTimeseries `json:"probabilityOfThunder"`
+	DavisStabilityIndex              *ForecastTimeseries `json:"davisStabilityIndex"`
+	AtmosphericDispersionIndex       *ForecastTimeseries `json:"atmosphericDispersionIndex"`
+	LowVisibilityOccurrenceRiskIndex *ForecastTimeseries `json:"lowVisibilityOccurrenceRiskIndex"`
+	Stability                        *ForecastTimeseries `json:"stability"`
+	RedFlagThreatIndex               *ForecastTimeseries `json:"redFlagThreatIndex"`
+
+	// Weather holds the expected weather conditions, see WeatherCondition
+	Weather *WeatherTimeseries `json:"weather"`
+	// Hazards holds the watches, warnings and advisories in effect, see Hazard
+	Hazards *HazardTimeseries `json:"hazards"`
+
+	// Layers holds every numeric layer of the response keyed by its API name,
+	// including the ones above and any the API adds later
+	Layers map[string]*ForecastTimeseries `json:"-"`
+}
+
+// gridLayer maps a typed ForecastGridResponse field to its API name
+type gridLayer struct {
+	name    string
+	apiName string
+	field   func(f *ForecastGridResponse) **ForecastTimeseries
+}
+
+var gridLayers = []gridLayer{
+	{"Temperature", "temperature", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.Temperature }},
+	{"Dewpoint", "dewpoint", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.Dewpoint }},
+	{"MaxTemperature", "maxTemperature", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.MaxTemperature }},
+	{"MinTemperature", "minTemperature", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.MinTemperature }},
+	{"RelativeHumidity", "relativeHumidity", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.RelativeHumidity }},
+	{"ApparentTemperature", "apparentTemperature", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.ApparentTemperature }},
+	{"HeatIndex", "heatIndex", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.HeatIndex }},
+	{"WindChill", "windChill", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.WindChill }},
+	{"SkyCover", "skyCover", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.SkyCover }},
+	{"WindDirection", "windDirection", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.WindDirection }},
+	{"WindSpeed", "windSpeed", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.WindSpeed }},
+	{"WindGust", "windGust", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.WindGust }},
+	{"PrecipitationProbability", "probabilityOfPrecipitation", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PrecipitationProbability }},
+	{"PrecipitationQuantity", "quantitativePrecipitation", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PrecipitationQuantity }},
+	{"IceAccumulation", "iceAccumulation", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.IceAccumulation }},
+	{"SnowFallAmount", "snowfallAmount", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.SnowFallAmount }},
+	{"SnowLevel", "snowLevel", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.SnowLevel }},
+	{"CeilingHeight", "ceilingHeight", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.CeilingHeight }},
+	{"Visibility", "visibility", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.Visibility }},
+	{"TransportWindSpeed", "transportWindSpeed", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.TransportWindSpeed }},
+	{"TransportWindDirection", "transportWindDirection", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.TransportWindDirection }},
+	{"MixingHeight", "mixingHeight", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.MixingHeight }},
+	{"HainesIndex", "hainesIndex", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.HainesIndex }},
+	{"LightningActivityLevel", "lightningActivityLevel", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.LightningActivityLevel }},
+	{"TwentyFootWindSpeed", "twentyFootWindSpeed", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.TwentyFootWindSpeed }},
+	{"TwentyFootWindDirection", "twentyFootWindDirection", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.TwentyFootWindDirection }},
+	{"WaveHeight", "waveHeight", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.WaveHeight }},
+	{"WavePeriod", "wavePeriod", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.WavePeriod }},
+	{"WaveDirection", "waveDirection", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.WaveDirection }},
+	{"PrimarySwellHeight", "primarySwellHeight", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PrimarySwellHeight }},
+	{"PrimarySwellDirection", "primarySwellDirection", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PrimarySwellDirection }},
+	{"SecondarySwellHeight", "secondarySwellHeight", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.SecondarySwellHeight }},
+	{"SecondarySwellDirection", "secondarySwellDirection", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.SecondarySwellDirection }},
+	{"WavePeriod2", "wavePeriod2", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.WavePeriod2 }},
+	{"WindWaveHeight", "windWaveHeight", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.WindWaveHeight }},
+	{"DispersionIndex", "dispersionIndex", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.DispersionIndex }},
+	{"Pressure", "pressure", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.Pressure }},
+	{"TropicalStormWindsProbability", "probabilityOfTropicalStormWinds", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.TropicalStormWindsProbability }},
+	{"HurricaneWindsProbability", "probabilityOfHurricaneWinds", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.HurricaneWindsProbability }},
+	{"PotentialOf15mphWinds", "potentialOf15mphWinds", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PotentialOf15mphWinds }},
+	{"PotentialOf25mphWinds", "potentialOf25mphWinds", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PotentialOf25mphWinds }},
+	{"PotentialOf35mphWinds", "potentialOf35mphWinds", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PotentialOf35mphWinds }},
+	{"PotentialOf45mphWinds", "potentialOf45mphWinds", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PotentialOf45mphWinds }},
+	{"PotentialOf20mphWindGusts", "potentialOf20mphWindGusts", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PotentialOf20mphWindGusts }},
+	{"PotentialOf30mphWindGusts", "potentialOf30mphWindGusts", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PotentialOf30mphWindGusts }},
+	{"PotentialOf40mphWindGusts", "potentialOf40mphWindGusts", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PotentialOf40mphWindGusts }},
+	{"PotentialOf50mphWindGusts", "potentialOf50mphWindGusts", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PotentialOf50mphWindGusts }},
+	{"PotentialOf60mphWindGusts", "potentialOf60mphWindGusts", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.PotentialOf60mphWindGusts }},
+	{"GrasslandFireDangerIndex", "grasslandFireDangerIndex", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.GrasslandFireDangerIndex }},
+	{"ThunderProbability", "probabilityOfThunder", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.ThunderProbability }},
+	{"DavisStabilityIndex", "davisStabilityIndex", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.DavisStabilityIndex }},
+	{"AtmosphericDispersionIndex", "atmosphericDispersionIndex", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.AtmosphericDispersionIndex }},
+	{"LowVisibilityOccurrenceRiskIndex", "lowVisibilityOccurrenceRiskIndex", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.LowVisibilityOccurrenceRiskIndex }},
+	{"Stability", "stability", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.Stability }},
+	{"RedFlagThreatIndex", "redFlagThreatIndex", func(f *ForecastGridResponse) **ForecastTimeseries { return &f.RedFlagThreatIndex }},
+}
+
+// nonNumericLayers hold objects instead of numbers and are decoded separately
+var nonNumericLayers = map[string]bool{
+	"weather": true,
+	"hazards": true,
+}
+
+func gridLayerByAPIName(apiName string) (gridLayer, bool) {
+	for _, layer := range gridLayers {
+		if layer.apiName == apiName {
+			return layer, true
+		}
+	}
+	return gridLayer{}, false
+}
+
+func gridLayerByName(name string) (gridLayer, bool) {
+	for _, layer := range gridLayers {
+		if layer.name == name {
+			return layer, true
+		}
+	}
+	return gridLayer{}, false
+}
+
+// UnmarshalJSON decodes the typed layers and collects every numeric layer in Layers
+func (f *ForecastGridResponse) UnmarshalJSON(buf []byte) error {
+	type gridResponse ForecastGridResponse
+	if err := json.Unmarshal(buf, (*gridResponse)(f)); err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return err
+	}
+	f.Layers = make(map[string]*ForecastTimeseries)
+	for key, msg := range raw {
+		if nonNumericLayers[key] {
+			continue
+		}
+		if layer, ok := gridLayerByAPIName(key); ok {
+			if ts := *layer.field(f); ts != nil {
+				f.Layers[key] = ts
+			}
+			continue
+		}
+		var ts ForecastTimeseries
+		if err := json.Unmarshal(msg, &ts); err != nil || ts.Values == nil {
+			// not a layer of numeric values
+			continue
+		}
+		f.Layers[key] = &ts
+	}
+	return nil
+}
+
+// timeseriesMap returns the layers with values, keyed by field name for the
+// typed layers and by API name for the others
+func (f *ForecastGridResponse) timeseriesMap() map[string]*ForecastTimeseries {
+	timeseries := make(map[string]*ForecastTimeseries, 0)
+	for _, layer := range gridLayers {
+		if ts := *layer.field(f); ts != nil && len(ts.Values) > 0 {
+			timeseries[layer.name] = ts.fillInfo(layer.name, f.ID)
+		}
+	}
+	for apiName, ts := range f.Layers {
+		if _, ok := gridLayerByAPIName(apiName); !ok && len(ts.Values) > 0 {
+			timeseries[apiName] = ts.fillInfo(apiName, f.ID)
+		}
+	}
+	return timeseries
+}
+
+// timeseriesNames orders the keys of a timeseriesMap: typed layers first, then the others by name
+func timeseriesNames(timeseriesMap map[string]*ForecastTimeseries) []string {
+	names := make([]string, 0, len(timeseriesMap))
+	for _, layer := range gridLayers {
+		if _, ok := timeseriesMap[layer.name]; ok {
+			names = append(names, layer.name)
+		}
+	}
+	extra := make([]string, 0)
+	for name := range timeseriesMap {
+		if _, ok := gridLayerByName(name); !ok {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	return append(names, extra...)
+}
+
+func newForecastGridResponse(updated time.Time, validTimes *ForecastTime, elevation forecastElevation, timeseriesMap map[string]*ForecastTimeseries) (*ForecastGridResponse, error) {
+	grid := &ForecastGridResponse{
+		Updated:    updated,
+		ValidTimes: validTimes,
+		Elevation:  elevation,
+		Layers:     make(map[string]*ForecastTimeseries),
+	}
+	for name, ts := range timeseriesMap {
+		if layer, ok := gridLayerByName(name); ok {
+			*layer.field(grid) = ts
+			grid.Layers[layer.apiName] = ts
+		} else {
+			grid.Layers[name] = ts
+		}
+	}
+	return grid, nil
+}
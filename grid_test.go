@@ -0,0 +1,52 @@
+package noaa
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridLayers(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+
+	assert.Equal(t, 133, len(fcst.Dewpoint.Values))
+	assert.Equal(t, "unit:percent", fcst.RelativeHumidity.Units)
+	assert.Equal(t, 37, len(fcst.WindGust.Values))
+	assert.Equal(t, 0, len(fcst.IceAccumulation.Values))
+	assert.True(t, fcst.Layers["dewpoint"] == fcst.Dewpoint)
+	assert.True(t, fcst.Layers["probabilityOfPrecipitation"] == fcst.PrecipitationProbability)
+	assert.NotContains(t, fcst.Layers, "weather")
+	assert.NotContains(t, fcst.Layers, "elevation")
+	assert.Equal(t, 55, len(fcst.Layers))
+}
+
+func TestGridUnknownLayer(t *testing.T) {
+	var fcst ForecastGridResponse
+	check(json.Unmarshal([]byte(`{
+		"validTimes": "2019-10-27T16:00:00+00:00/PT2H",
+		"temperature": {"uom": "unit:degC", "values": [{"validTime": "2019-10-27T16:00:00+00:00/PT2H", "value": 1}]},
+		"soilMoisture": {"uom": "unit:percent", "values": [{"validTime": "2019-10-27T16:00:00+00:00/PT2H", "value": 20}]}
+	}`), &fcst))
+	assert.Equal(t, 20.0, fcst.Layers["soilMoisture"].Values[0].Value)
+
+	timeseries := fcst.timeseriesMap()
+	assert.Equal(t, []string{"Temperature", "soilMoisture"}, timeseriesNames(timeseries))
+}
+
+func TestCreateForecastHourlyLayers(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	hourly, err := CreateForecastHourly(fcst)
+	check(err)
+
+	assert.Equal(t, "Temperature", hourly.SeriesNames[0])
+	assert.Contains(t, hourly.SeriesNames, "Dewpoint")
+	assert.Contains(t, hourly.SeriesNames, "MixingHeight")
+	assert.NotContains(t, hourly.SeriesNames, "IceAccumulation")
+	assert.Equal(t, len(hourly.SeriesNames), len(hourly.Values))
+	for _, values := range hourly.Values {
+		assert.Equal(t, len(hourly.Times), len(values))
+	}
+}
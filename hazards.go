@@ -0,0 +1,208 @@
+package noaa
+
+import "time"
+
+// Hazard is a VTEC phenomenon / significance pair from the gridpoint hazards layer,
+// e.g. WS.A for a winter storm watch
+type Hazard struct {
+	Phenomenon   string `json:"phenomenon"`
+	Significance string `json:"significance"`
+	EventNumber  *int   `json:"event_number"`
+}
+
+// HazardTimeseries holds the hazards layer from within ForecastGridResponse
+type HazardTimeseries struct {
+	Values []*HazardTimeseriesValue `json:"values"`
+}
+
+// HazardTimeseriesValue holds the hazards in effect during one time interval
+type HazardTimeseriesValue struct {
+	Time  ForecastTime `json:"validTime"`
+	Value []Hazard     `json:"value"`
+}
+
+// Severity ranks of the VTEC significance codes, higher is more severe
+const (
+	SeverityNone      = 0
+	SeverityStatement = 1
+	SeverityAdvisory  = 2
+	SeverityWatch     = 3
+	SeverityWarning   = 4
+)
+
+// vtecSignificance maps a VTEC significance code to its name and severity
+var vtecSignificance = map[string]struct {
+	name     string
+	severity int
+}{
+	"W": {"Warning", SeverityWarning},
+	"A": {"Watch", SeverityWatch},
+	"Y": {"Advisory", SeverityAdvisory},
+	"S": {"Statement", SeverityStatement},
+	"F": {"Forecast", SeverityNone},
+	"O": {"Outlook", SeverityNone},
+	"N": {"Synopsis", SeverityNone},
+}
+
+// vtecPhenomena maps a VTEC phenomenon code to its name
+var vtecPhenomena = map[string]string{
+	"AF": "Ashfall",
+	"AS": "Air Stagnation",
+	"BH": "Beach Hazards",
+	"BS": "Blowing Snow",
+	"BW": "Brisk Wind",
+	"BZ": "Blizzard",
+	"CF": "Coastal Flood",
+	"CW": "Cold Weather",
+	"DF": "Debris Flow",
+	"DS": "Dust Storm",
+	"DU": "Blowing Dust",
+	"EC": "Extreme Cold",
+	"EH": "Excessive Heat",
+	"EW": "Extreme Wind",
+	"FA": "Areal Flood",
+	"FF": "Flash Flood",
+	"FG": "Dense Fog",
+	"FL": "Flood",
+	"FR": "Frost",
+	"FW": "Fire Weather",
+	"FZ": "Freeze",
+	"GL": "Gale",
+	"HF": "Hurricane Force Wind",
+	"HT": "Heat",
+	"HU": "Hurricane",
+	"HW": "High Wind",
+	"HY": "Hydrologic",
+	"HZ": "Hard Freeze",
+	"IS": "Ice Storm",
+	"LE": "Lake Effect Snow",
+	"LO": "Low Water",
+	"LS": "Lakeshore Flood",
+	"LW": "Lake Wind",
+	"MA": "Marine",
+	"MF": "Dense Fog",
+	"MH": "Ashfall",
+	"MS": "Dense Smoke",
+	"RB": "Small Craft for Rough Bar",
+	"RP": "Rip Current Risk",
+	"SC": "Small Craft",
+	"SE": "Hazardous Seas",
+	"SI": "Small Craft for Winds",
+	"SM": "Dense Smoke",
+	"SQ": "Snow Squall",
+	"SR": "Storm",
+	"SS": "Storm Surge",
+	"SU": "High Surf",
+	"SV": "Severe Thunderstorm",
+	"SW": "Small Craft for Hazardous Seas",
+	"TO": "Tornado",
+	"TR": "Tropical Storm",
+	"TS": "Tsunami",
+	"TY": "Typhoon",
+	"UP": "Heavy Freezing Spray",
+	"WC": "Wind Chill",
+	"WI": "Wind",
+	"WS": "Winter Storm",
+	"WW": "Winter Weather",
+	"XH": "Extreme Heat",
+	"ZF": "Freezing Fog",
+	"ZR": "Freezing Rain",
+	"ZY": "Freezing Spray",
+}
+
+// vtecNames overrides the names of codes that are not "<phenomenon> <significance>"
+var vtecNames = map[string]string{
+	"FW.W": "Red Flag Warning",
+	"MA.W": "Special Marine Warning",
+	"MA.S": "Marine Weather Statement",
+	"HY.S": "Hydrologic Statement",
+	"HY.O": "Hydrologic Outlook",
+}
+
+// Code is the VTEC code, e.g. "WS.A"
+func (h Hazard) Code() string {
+	return h.Phenomenon + "." + h.Significance
+}
+
+// Name is the human name, e.g. "Winter Storm Watch", or the code when unknown
+func (h Hazard) Name() string {
+	if name, ok := vtecNames[h.Code()]; ok {
+		return name
+	}
+	phenomenon, ok := vtecPhenomena[h.Phenomenon]
+	significance, okSig := vtecSignificance[h.Significance]
+	if !ok || !okSig {
+		return h.Code()
+	}
+	return phenomenon + " " + significance.name
+}
+
+// Severity ranks the hazard by its significance, from SeverityNone to SeverityWarning
+func (h Hazard) Severity() int {
+	return vtecSignificance[h.Significance].severity
+}
+
+// At returns the hazards in effect at each of times, e.g. ForecastHourly.Times.
+// Intervals may overlap, e.g. a watch spanning a shorter advisory, so every
+// interval containing a time contributes its hazards.
+func (ht *HazardTimeseries) At(times []time.Time) [][]Hazard {
+	out := make([][]Hazard, len(times))
+	for i, t := range times {
+		out[i] = []Hazard{}
+		for _, v := range ht.Values {
+			if t.Before(v.Time.Time) || !t.Before(v.Time.endTime()) {
+				continue
+			}
+			for _, h := range v.Value {
+				if !containsHazard(out[i], h) {
+					out[i] = append(out[i], h)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// containsHazard is true when hazards already holds the same code and event number
+func containsHazard(hazards []Hazard, h Hazard) bool {
+	for _, other := range hazards {
+		if other.Code() != h.Code() || (other.EventNumber == nil) != (h.EventNumber == nil) {
+			continue
+		}
+		if h.EventNumber == nil || *other.EventNumber == *h.EventNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSeverity is the highest severity among hazards, SeverityNone when empty
+func MaxSeverity(hazards []Hazard) int {
+	severity := SeverityNone
+	for _, h := range hazards {
+		if h.Severity() > severity {
+			severity = h.Severity()
+		}
+	}
+	return severity
+}
+
+// HazardSeverity returns the highest hazard severity of each hour
+func (h *ForecastHourly) HazardSeverity() []int {
+	out := make([]int, len(h.Times))
+	for i := range h.Hazards {
+		out[i] = MaxSeverity(h.Hazards[i])
+	}
+	return out
+}
+
+// RiskyHours returns the hours with a hazard of at least minSeverity, e.g. SeverityWatch
+func (h *ForecastHourly) RiskyHours(minSeverity int) []time.Time {
+	out := make([]time.Time, 0)
+	for i, severity := range h.HazardSeverity() {
+		if severity >= minSeverity && severity > SeverityNone {
+			out = append(out, h.Times[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package noaa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHazardNames(t *testing.T) {
+	assert.Equal(t, "Winter Storm Watch", Hazard{Phenomenon: "WS", Significance: "A"}.Name())
+	assert.Equal(t, "Red Flag Warning", Hazard{Phenomenon: "FW", Significance: "W"}.Name())
+	assert.Equal(t, "Fire Weather Watch", Hazard{Phenomenon: "FW", Significance: "A"}.Name())
+	assert.Equal(t, "QQ.A", Hazard{Phenomenon: "QQ", Significance: "A"}.Name())
+	assert.Equal(t, SeverityWarning, Hazard{Phenomenon: "TO", Significance: "W"}.Severity())
+	assert.Equal(t, SeverityAdvisory, MaxSeverity([]Hazard{{"FR", "Y", nil}, {"BH", "S", nil}}))
+	assert.Equal(t, SeverityNone, MaxSeverity(nil))
+}
+
+func TestHazardsHourly(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	assert.Equal(t, 0, len(fcst.Hazards.Values))
+	check(json.Unmarshal([]byte(`{"values": [
+		{"validTime": "2019-10-28T12:00:00+00:00/PT12H", "value": [{"phenomenon": "WS", "significance": "A", "event_number": 3}]},
+		{"validTime": "2019-10-29T00:00:00+00:00/PT6H", "value": [
+			{"phenomenon": "WS", "significance": "W", "event_number": 3},
+			{"phenomenon": "WC", "significance": "Y", "event_number": null}
+		]}
+	]}`), &fcst.Hazards))
+
+	hourly, err := CreateForecastHourly(fcst)
+	check(err)
+	assert.Equal(t, len(hourly.Times), len(hourly.Hazards))
+	assert.Equal(t, 18, len(hourly.RiskyHours(SeverityWatch)))
+	assert.Equal(t, 6, len(hourly.RiskyHours(SeverityWarning)))
+	assert.Equal(t, time.Date(2019, 10, 29, 0, 0, 0, 0, time.UTC), hourly.RiskyHours(SeverityWarning)[0])
+
+	hazards := fcst.Hazards.At([]time.Time{time.Date(2019, 10, 29, 1, 0, 0, 0, time.UTC)})
+	assert.Equal(t, "Wind Chill Advisory", hazards[0][1].Name())
+	assert.Equal(t, 3, *hazards[0][0].EventNumber)
+}
+
+func TestHazardsOverlapping(t *testing.T) {
+	var hazards HazardTimeseries
+	check(json.Unmarshal([]byte(`{"values": [
+		{"validTime": "2019-10-28T00:00:00+00:00/PT24H", "value": [{"phenomenon": "WS", "significance": "A", "event_number": 3}]},
+		{"validTime": "2019-10-28T06:00:00+00:00/PT3H", "value": [
+			{"phenomenon": "WW", "significance": "Y", "event_number": 4},
+			{"phenomenon": "WS", "significance": "A", "event_number": 3}
+		]}
+	]}`), &hazards))
+
+	at := hazards.At([]time.Time{
+		time.Date(2019, 10, 28, 7, 0, 0, 0, time.UTC),
+		time.Date(2019, 10, 28, 12, 0, 0, 0, time.UTC),
+		time.Date(2019, 10, 29, 0, 0, 0, 0, time.UTC),
+	})
+	assert.Equal(t, 2, len(at[0]))
+	assert.Equal(t, "WS.A", at[0][0].Code())
+	assert.Equal(t, "WW.Y", at[0][1].Code())
+	assert.Equal(t, 1, len(at[1]))
+	assert.Equal(t, "WS.A", at[1][0].Code())
+	assert.Empty(t, at[2])
+}
@@ -2,169 +2,133 @@ package noaa
 
 import (
 	"fmt"
-	"math"
 	"strings"
 	"time"
-)
 
-var seriesNames = []string{
-	"Temperature",
-	"SkyCover",
-	"WindSpeed",
-	"PrecipitationProbability",
-	"PrecipitationQuantity",
-	"SnowFallAmount",
-	"SnowLevel",
-}
+	"github.com/adamgreenhall/noaa/units"
+)
 
 // ForecastHourly is a more compact form of noaa.ForecastGridResponse
 type ForecastHourly struct {
-	CreatedAt       time.Time   `json:"createdAt"`
-	ElevationMeters int64       `json:"elevationMeters"`
-	Endpoint        string      `json:"endpoint"`
-	Times           []time.Time `json:"times"`
-	SeriesNames     []string    `json:"seriesNames"`
-	Units           []string    `json:"units"`
-	Values          [][]float64 `json:"values"`
+	CreatedAt       time.Time `json:"createdAt"`
+	ElevationMeters int64     `json:"elevationMeters"`
+	Endpoint        string    `json:"endpoint"`
+	// Timezone is the IANA timezone of the point, see LocalTimes
+	Timezone    string      `json:"timezone,omitempty"`
+	Times       []time.Time `json:"times"`
+	SeriesNames []string    `json:"seriesNames"`
+	Units       []string    `json:"units"`
+	Values      [][]float64 `json:"values"`
+	// Weather holds the conditions for each of Times when the grid has a weather layer
+	Weather [][]WeatherCondition `json:"weather,omitempty"`
+	// Hazards holds the hazards for each of Times when the grid has a hazards layer
+	Hazards [][]Hazard `json:"hazards,omitempty"`
 }
 
+// hourly forward fills the timeseries onto whole hours from tMin to tMax
 func (ts *ForecastTimeseries) hourly(tMin, tMax time.Time) (*ForecastTimeseries, error) {
-	Nhours := int(tMax.Sub(tMin).Hours()) + 1
-	tsTmin := ts.Tmin()
-	tsTmax := ts.Tmax()
-	lenTs := len(ts.Values)
-	msgDebugging := (fmt.Sprintf("original series: len=%03d, tmin=%s, tmax=%s\n", lenTs, tsTmin.Format(timeFormat), tsTmax.Format(timeFormat)) +
-		fmt.Sprintf("hourly series  : len=%03d, tmin=%s, tmax=%s", Nhours, tMin.Format(timeFormat), tMax.Format(timeFormat)))
+	return ts.hourlyMethod(tMin, tMax, ResampleStep)
+}
 
-	out := make([]*ForecastTimeseriesValue, Nhours)
-	hr := 0
-	firstValueSeries := ts.Values[0]
-	padHoursStart := int(tsTmin.Sub(tMin).Hours())
-	for i := 0; i < padHoursStart; i++ {
-		out[hr] = &ForecastTimeseriesValue{
-			Time: ForecastTime{
-				Time:     tMin.Add(time.Duration(i) * time.Hour),
-				Duration: time.Hour,
-			},
-			Value: firstValueSeries.Value,
-		}
-		hr++
-	}
-	for _, t := range ts.Values {
-		if hr > 0 && hr < Nhours {
-			// subsequent values in the original time series sometimes have gaps - interpolate using last value
-			lastValue := out[hr-1]
-			hrsInterpolate := int(t.Time.Time.Sub(lastValue.Time.Time).Hours())
-			for j := 1; j < hrsInterpolate; j++ {
-				out[hr] = &ForecastTimeseriesValue{
-					Time: ForecastTime{
-						Time:     lastValue.Time.Time.Add(time.Duration(j) * time.Hour),
-						Duration: time.Hour,
-					},
-					Value: lastValue.Value,
-				}
-				hr++
-			}
-		}
-		hrsSpan := int(t.Time.Duration.Hours())
-		for i := 0; i < hrsSpan; i++ {
-			if hr >= Nhours {
-				// reached the end of the hourly series
-				// the original series may have extra data (exists outside of the forecast's valid range),
-				// but we're cutting it off
-				break
-			}
-			out[hr] = &ForecastTimeseriesValue{
-				Time: ForecastTime{
-					Time:     t.Time.Time.Add(time.Duration(i) * time.Hour),
-					Duration: time.Hour,
-				},
-				Value: t.Value,
-			}
-			hr++
-		}
+func (ts *ForecastTimeseries) hourlyMethod(tMin, tMax time.Time, method ResampleMethod) (*ForecastTimeseries, error) {
+	// the hourly series works on whole hours
+	return ts.snapped(time.Hour).resample(tMin, tMax, time.Hour, method)
+}
+
+// CreateForecastHourly builds a ForecastHourly from noaa.ForecastGridResponse,
+// resampling each series with its DefaultResampleMethod
+func CreateForecastHourly(grid *ForecastGridResponse) (*ForecastHourly, error) {
+	validTimes := grid.ValidTimes.Snap(time.Hour)
+	regular, err := createForecastRegular(grid, validTimes.Time, validTimes.endTime(), time.Hour, true)
+	if err != nil {
+		return nil, err
 	}
-	// fill values at end of timeseries
-	lastValue := out[hr-1]
-	padHoursEnd := Nhours - hr
-	for i := 1; i <= padHoursEnd; i++ {
-		out[hr+i-1] = &ForecastTimeseriesValue{
-			Time: ForecastTime{
-				Time:     lastValue.Time.Time.Add(time.Duration(i) * time.Hour),
-				Duration: time.Hour,
-			},
-			Value: lastValue.Value,
-		}
+	return &ForecastHourly{
+		CreatedAt:       regular.CreatedAt,
+		ElevationMeters: regular.ElevationMeters,
+		Endpoint:        regular.Endpoint,
+		Timezone:        regular.Timezone,
+		Times:           regular.Times,
+		SeriesNames:     regular.SeriesNames,
+		Units:           regular.Units,
+		Values:          regular.Values,
+		Weather:         regular.Weather,
+		Hazards:         regular.Hazards,
+	}, nil
+}
+
+// unitSymbol is the short label of a unit code, e.g. "C" for "unit:degC"
+func unitSymbol(code string) string {
+	u, err := units.Parse(code)
+	if err != nil {
+		return strings.ReplaceAll(code, "unit:", "")
 	}
-	firstHourlyValue := out[0]
-	lastHourlyValue := out[Nhours-1]
-	if firstHourlyValue.Time.Time != tMin {
-		return nil, fmt.Errorf(
-			"start times do not match for %s at %s.\nexpected=%s\nfound=   %s\n%s",
-			ts.Name, ts.ID,
-			tMin.Format(timeFormat), firstHourlyValue.Time.Time.Format(timeFormat),
-			msgDebugging,
-		)
+	return u.Symbol
+}
+
+// ToSystem returns a copy with every series in its metric or imperial units
+func (h *ForecastHourly) ToSystem(system units.System) (*ForecastHourly, error) {
+	targets, err := systemTargets(h.SeriesNames, h.Units, system)
+	if err != nil {
+		return nil, err
 	}
-	if lastHourlyValue.Time.Time != tMax {
-		return nil, fmt.Errorf(
-			"end times do not match for %s at %s.\nexpected=%s\nfound=   %s\n%s",
-			ts.Name, ts.ID,
-			tMax.Format(timeFormat), lastHourlyValue.Time.Time.Format(timeFormat),
-			msgDebugging,
-		)
+	return h.Convert(targets)
+}
+
+// Convert returns a copy with the series named in targets converted to the
+// given unit codes, e.g. {"Temperature": "unit:degF"}
+func (h *ForecastHourly) Convert(targets map[string]string) (*ForecastHourly, error) {
+	seriesUnits, values, err := convertSeries(h.SeriesNames, h.Units, h.Values, targets)
+	if err != nil {
+		return nil, err
 	}
-	return &ForecastTimeseries{
-		Name:   ts.Name,
-		ID:     ts.ID,
-		Values: out,
-		Units:  ts.Units,
-	}, nil
+	out := *h
+	out.Units = seriesUnits
+	out.Values = values
+	return &out, nil
 }
 
-// CreateForecastHourly builds a ForecastHourly from noaa.ForecastGridResponse
-func CreateForecastHourly(grid *ForecastGridResponse) (*ForecastHourly, error) {
-	hourlyTimeseries := make(map[string]*ForecastTimeseries)
-	for k, ts := range grid.timeseriesMap() {
-		ts, err := ts.hourly(grid.ValidTimes.Time, grid.ValidTimes.endTime())
+// systemTargets maps each series to the unit code of its counterpart in system
+func systemTargets(seriesNames []string, seriesUnits []string, system units.System) (map[string]string, error) {
+	targets := make(map[string]string, len(seriesNames))
+	for i, name := range seriesNames {
+		from, err := units.Parse(seriesUnits[i])
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("series %s: %s", name, err.Error())
 		}
-		hourlyTimeseries[k] = ts
+		targets[name] = from.In(system).Code
 	}
-	times := make([]time.Time, len(hourlyTimeseries["Temperature"].Values))
-	for i, val := range hourlyTimeseries["Temperature"].Values {
-		times[i] = val.Time.Time
-	}
-	values := make([][]float64, len(seriesNames))
-	units := make([]string, len(seriesNames))
-	for i, nm := range seriesNames {
-		ts, ok := hourlyTimeseries[nm]
+	return targets, nil
+}
+
+// convertSeries returns copies of seriesUnits and values with the series named in targets converted
+func convertSeries(seriesNames []string, seriesUnits []string, values [][]float64, targets map[string]string) ([]string, [][]float64, error) {
+	outUnits := make([]string, len(seriesUnits))
+	outValues := make([][]float64, len(values))
+	copy(outUnits, seriesUnits)
+	copy(outValues, values)
+	for i, name := range seriesNames {
+		code, ok := targets[name]
 		if !ok {
-			return nil, fmt.Errorf("could not find series %s", nm)
+			continue
 		}
-		units[i] = strings.ReplaceAll(
-			strings.ReplaceAll(
-				strings.ReplaceAll(ts.Units, "unit:", ""),
-				"m_s-1", "m/s"),
-			"degC",
-			"C")
-		values[i] = make([]float64, len(times))
-		for j, val := range ts.Values {
-			// round to one digit precision
-			values[i][j] = math.Round(val.Value*10) / 10
+		from, err := units.Parse(seriesUnits[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("series %s: %s", name, err.Error())
+		}
+		to, err := units.Parse(code)
+		if err != nil {
+			return nil, nil, fmt.Errorf("series %s: %s", name, err.Error())
+		}
+		outUnits[i] = to.Symbol
+		outValues[i] = make([]float64, len(values[i]))
+		for j, value := range values[i] {
+			converted, err := units.Convert(value, from, to)
+			if err != nil {
+				return nil, nil, fmt.Errorf("series %s: %s", name, err.Error())
+			}
+			outValues[i][j] = roundSeries(name, converted)
 		}
 	}
-	if !strings.HasSuffix(strings.ToLower(grid.Elevation.Units), "unit:m") {
-		return nil, fmt.Errorf("unknown elevation units: %s", grid.Elevation.Units)
-	}
-	return &ForecastHourly{
-		CreatedAt:       grid.Updated,
-		ElevationMeters: int64(grid.Elevation.Value),
-		Endpoint:        grid.ID,
-		Times:           times,
-		SeriesNames:     seriesNames,
-		Units:           units,
-		Values:          values,
-	}, nil
+	return outUnits, outValues, nil
 }
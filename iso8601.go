@@ -0,0 +1,161 @@
+package noaa
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ISODuration is an ISO 8601 duration such as "P1DT2H", "P1W" or "PT0.5H".
+// Years and months are calendar units, their length depends on the start time.
+type ISODuration struct {
+	Years   float64
+	Months  float64
+	Weeks   float64
+	Days    float64
+	Hours   float64
+	Minutes float64
+	Seconds float64
+}
+
+const isoNumber = `(\d+(?:[.,]\d+)?)`
+
+var isoDurationRegex = regexp.MustCompile(
+	`^P(?:` + isoNumber + `Y)?(?:` + isoNumber + `M)?(?:` + isoNumber + `W)?(?:` + isoNumber + `D)?` +
+		`(?:T(?:` + isoNumber + `H)?(?:` + isoNumber + `M)?(?:` + isoNumber + `S)?)?$`)
+
+// ParseISODuration parses an ISO 8601 duration, components may be fractional
+func ParseISODuration(s string) (ISODuration, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(s))
+	matches := isoDurationRegex.FindStringSubmatch(normalized)
+	if matches == nil || strings.HasSuffix(normalized, "T") {
+		return ISODuration{}, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+	components := make([]float64, 7)
+	found := false
+	for i, match := range matches[1:] {
+		if match == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.Replace(match, ",", ".", 1), 64)
+		if err != nil {
+			return ISODuration{}, fmt.Errorf("invalid ISO 8601 duration %q: %s", s, err.Error())
+		}
+		components[i] = value
+		found = true
+	}
+	if !found {
+		return ISODuration{}, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+	return ISODuration{
+		Years:   components[0],
+		Months:  components[1],
+		Weeks:   components[2],
+		Days:    components[3],
+		Hours:   components[4],
+		Minutes: components[5],
+		Seconds: components[6],
+	}, nil
+}
+
+// clock is the part of the duration with a fixed length
+func (d ISODuration) clock() time.Duration {
+	return time.Duration((d.Hours*3600 + d.Minutes*60 + d.Seconds) * float64(time.Second))
+}
+
+// addTo adds sign (1 or -1) times the duration to t. Whole years, months and
+// days are added on the calendar, fractions of them as average lengths.
+func (d ISODuration) addTo(t time.Time, sign int) time.Time {
+	years, yearFrac := math.Modf(d.Years)
+	months, monthFrac := math.Modf(d.Months)
+	days, dayFrac := math.Modf(d.Weeks*7 + d.Days)
+	t = t.AddDate(sign*int(years), sign*int(months), sign*int(days))
+	const day = 24 * float64(time.Hour)
+	frac := time.Duration((yearFrac*365.2425 + monthFrac*365.2425/12 + dayFrac) * day)
+	return t.Add(time.Duration(sign) * (frac + d.clock()))
+}
+
+// AddTo returns t plus the duration
+func (d ISODuration) AddTo(t time.Time) time.Time {
+	return d.addTo(t, 1)
+}
+
+// SubtractFrom returns t minus the duration
+func (d ISODuration) SubtractFrom(t time.Time) time.Time {
+	return d.addTo(t, -1)
+}
+
+// Duration is the length of the duration using average year and month lengths,
+// use AddTo for the exact length from a given start
+func (d ISODuration) Duration() time.Duration {
+	start := time.Time{}
+	if d.Years == 0 && d.Months == 0 {
+		return d.AddTo(start).Sub(start)
+	}
+	const day = 24 * float64(time.Hour)
+	calendar := time.Duration((d.Years*365.2425 + d.Months*365.2425/12 + d.Weeks*7 + d.Days) * day)
+	return calendar + d.clock()
+}
+
+var isoTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseISOTime parses an ISO 8601 date-time with any offset, times without one are UTC
+func parseISOTime(s string) (time.Time, error) {
+	for _, layout := range isoTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid ISO 8601 time %q", s)
+}
+
+// ParseInterval parses an ISO 8601 time interval given as start/duration,
+// start/end or duration/end. The start is returned in UTC and the duration is exact.
+func ParseInterval(s string) (ForecastTime, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 2 {
+		return ForecastTime{}, fmt.Errorf("invalid ISO 8601 interval %q", s)
+	}
+	var start, end time.Time
+	var err error
+	switch {
+	case strings.HasPrefix(parts[0], "P"):
+		dur, err := ParseISODuration(parts[0])
+		if err != nil {
+			return ForecastTime{}, err
+		}
+		if end, err = parseISOTime(parts[1]); err != nil {
+			return ForecastTime{}, err
+		}
+		start = dur.SubtractFrom(end)
+	case strings.HasPrefix(parts[1], "P"):
+		if start, err = parseISOTime(parts[0]); err != nil {
+			return ForecastTime{}, err
+		}
+		dur, err := ParseISODuration(parts[1])
+		if err != nil {
+			return ForecastTime{}, err
+		}
+		end = dur.AddTo(start)
+	default:
+		if start, err = parseISOTime(parts[0]); err != nil {
+			return ForecastTime{}, err
+		}
+		if end, err = parseISOTime(parts[1]); err != nil {
+			return ForecastTime{}, err
+		}
+	}
+	if end.Before(start) {
+		return ForecastTime{}, fmt.Errorf("interval %q ends before it starts", s)
+	}
+	return ForecastTime{Time: start.UTC(), Duration: end.Sub(start)}, nil
+}
@@ -4,12 +4,12 @@
 package noaa
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 )
 
@@ -21,6 +21,14 @@ const (
 	timeFormat = time.RFC3339
 )
 
+// QuantitativeValue is a measurement and its unit code, Value is nil when missing.
+// Observations also carry a QualityControl flag, e.g. QCVerified.
+type QuantitativeValue struct {
+	Value          *float64 `json:"value"`
+	UnitCode       string   `json:"unitCode"`
+	QualityControl string   `json:"qualityControl,omitempty"`
+}
+
 // PointsResponse holds the JSON values from /points/<lat,lon>
 type PointsResponse struct {
 	ID                          string `json:"@id"`
@@ -34,11 +42,22 @@ type PointsResponse struct {
 	EndpointObservationStations string `json:"observationStations"`
 	Timezone                    string `json:"timeZone"`
 	RadarStation                string `json:"radarStation"`
+	ForecastZone                string `json:"forecastZone"`
+	County                      string `json:"county"`
+	FireWeatherZone             string `json:"fireWeatherZone"`
+	Geometry                    string `json:"geometry"`
+	// RelativeLocation is the nearest city, see Near
+	RelativeLocation RelativeLocation `json:"relativeLocation"`
+	// Location is parsed from Geometry
+	Location LatLon `json:"-"`
 }
 
 // StationsResponse holds the JSON values from /points/<lat,lon>/stations
 type StationsResponse struct {
+	// Stations are the station URLs in the order of Features
 	Stations []string `json:"observationStations"`
+	// Features holds the station metadata sorted by distance from the queried point
+	Features []*Station `json:"@graph"`
 }
 
 // ForecastResponse holds the JSON values from /gridpoints/<cwa>/<x,y>/forecast"
@@ -50,109 +69,72 @@ type ForecastResponse struct {
 		Value float64 `json:"value"`
 		Units string  `json:"unitCode"`
 	} `json:"elevation"`
-	Periods []struct {
-		ID              int32   `json:"number"`
-		Name            string  `json:"name"`
-		StartTime       string  `json:"startTime"`
-		EndTime         string  `json:"endTime"`
-		IsDaytime       bool    `json:"isDaytime"`
-		Temperature     float64 `json:"temperature"`
-		TemperatureUnit string  `json:"temperatureUnit"`
-		WindSpeed       string  `json:"windSpeed"`
-		WindDirection   string  `json:"windDirection"`
-		Summary         string  `json:"shortForecast"`
-		Details         string  `json:"detailedForecast"`
-	} `json:"periods"`
-	Point *PointsResponse
-}
-
-type forecastElevation struct {
-	Value float64 `json:"value"`
-	Units string  `json:"unitCode"`
-}
-
-// ForecastGridResponse holds the JSON values from /gridpoints/<cwa>/<x,y>
-type ForecastGridResponse struct {
-	ID                       string              `json:"@id"`
-	Updated                  time.Time           `json:"updateTime"`
-	ValidTimes               *ForecastTime       `json:"validTimes"`
-	Elevation                forecastElevation   `json:"elevation"`
-	Temperature              *ForecastTimeseries `json:"temperature"`
-	SkyCover                 *ForecastTimeseries `json:"skyCover"`
-	WindSpeed                *ForecastTimeseries `json:"windSpeed"`
-	PrecipitationProbability *ForecastTimeseries `json:"probabilityOfPrecipitation"`
-	PrecipitationQuantity    *ForecastTimeseries `json:"quantitativePrecipitation"`
-	SnowFallAmount           *ForecastTimeseries `json:"snowfallAmount"`
-	SnowLevel                *ForecastTimeseries `json:"snowLevel"`
-}
-
-func (f *ForecastGridResponse) timeseriesMap() map[string]*ForecastTimeseries {
-	timeseries := make(map[string]*ForecastTimeseries, 0)
-	timeseries["Temperature"] = f.Temperature.fillInfo("Temperature", f.ID)
-	timeseries["SkyCover"] = f.SkyCover.fillInfo("SkyCover", f.ID)
-	timeseries["WindSpeed"] = f.WindSpeed.fillInfo("WindSpeed", f.ID)
-	timeseries["PrecipitationProbability"] = f.PrecipitationProbability.fillInfo("PrecipitationProbability", f.ID)
-	timeseries["PrecipitationQuantity"] = f.PrecipitationQuantity.fillInfo("PrecipitationQuantity", f.ID)
-	timeseries["SnowFallAmount"] = f.SnowFallAmount.fillInfo("SnowFallAmount", f.ID)
-	timeseries["SnowLevel"] = f.SnowLevel.fillInfo("SnowLevel", f.ID)
-	return timeseries
-}
-
-func newForecastGridResponse(updated time.Time, validTimes *ForecastTime, elevation forecastElevation, timeseriesMap map[string]*ForecastTimeseries) (*ForecastGridResponse, error) {
-	// TODO: check keys exist
-	return &ForecastGridResponse{
-		Updated:                  updated,
-		ValidTimes:               validTimes,
-		Elevation:                elevation,
-		Temperature:              timeseriesMap["Temperature"],
-		SkyCover:                 timeseriesMap["SkyCover"],
-		PrecipitationProbability: timeseriesMap["PrecipitationProbability"],
-		PrecipitationQuantity:    timeseriesMap["PrecipitationQuantity"],
-		SnowFallAmount:           timeseriesMap["SnowFallAmount"],
-		SnowLevel:                timeseriesMap["SnowLevel"],
-		WindSpeed:                timeseriesMap["WindSpeed"],
-	}, nil
-}
-
-// Cache used for point lookup to save some HTTP round trips
-// key is expected to be PointsResponse.ID
-var pointsCache = map[string]*PointsResponse{}
-
-// Call the weather.gov API. We could just use http.Get() but
-// since we need to include some custom header values this helps.
-func apiCall(endpoint string) (res *http.Response, err error) {
-	endpoint = strings.Replace(endpoint, "http://", "https://", -1)
-	req, err := http.NewRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("Accept", APIAccept)
-	req.Header.Add("User-Agent", APIKey) // See http://www.weather.gov/documentation/services-web-api
+	Periods []ForecastPeriod `json:"periods"`
+	Point   *PointsResponse
+}
 
-	res, err = http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
+// ForecastPeriod holds one period of ForecastResponse, half a day for Forecast
+// or an hour for ForecastHourlyPeriods
+type ForecastPeriod struct {
+	ID                         int32             `json:"number"`
+	Name                       string            `json:"name"`
+	StartTime                  string            `json:"startTime"`
+	EndTime                    string            `json:"endTime"`
+	IsDaytime                  bool              `json:"isDaytime"`
+	Temperature                float64           `json:"temperature"`
+	TemperatureUnit            string            `json:"temperatureUnit"`
+	ProbabilityOfPrecipitation QuantitativeValue `json:"probabilityOfPrecipitation"`
+	Dewpoint                   QuantitativeValue `json:"dewpoint"`
+	RelativeHumidity           QuantitativeValue `json:"relativeHumidity"`
+	WindSpeed                  string            `json:"windSpeed"`
+	WindDirection              string            `json:"windDirection"`
+	Icon                       string            `json:"icon"`
+	Summary                    string            `json:"shortForecast"`
+	Details                    string            `json:"detailedForecast"`
 
-	if res.StatusCode == 404 {
-		defer res.Body.Close()
-		return nil, errors.New("404: data not found for -> " + endpoint)
-	}
-	if res.StatusCode != 200 {
-		defer res.Body.Close()
-		return nil, fmt.Errorf("%d: data not found for -> %s", res.StatusCode, endpoint)
-	}
-	return res, nil
+	// Start and End are parsed from StartTime and EndTime
+	Start time.Time `json:"-"`
+	End   time.Time `json:"-"`
+	// TemperatureValue is Temperature with the unit code of TemperatureUnit
+	TemperatureValue QuantitativeValue `json:"-"`
+	// DewpointValue is Dewpoint in the units of the forecast
+	DewpointValue QuantitativeValue `json:"-"`
+	// WindSpeedMin and WindSpeedMax are parsed from WindSpeed, e.g. "10 to 15 mph", in the
+	// units of the forecast. Both hold the same value for a single speed and are missing
+	// when it cannot be parsed.
+	WindSpeedMin QuantitativeValue `json:"-"`
+	WindSpeedMax QuantitativeValue `json:"-"`
+	// WindDirectionDegrees is parsed from the compass point in WindDirection, nil when missing
+	WindDirectionDegrees *float64 `json:"-"`
+	// Conditions are parsed from Icon
+	Conditions ForecastIcon `json:"-"`
 }
 
 // Points returns a set of useful endpoints for a given <lat,lon>
 // or returns a cached object if appropriate
-func Points(lat string, lon string) (points *PointsResponse, err error) {
-	endpoint := fmt.Sprintf("%s/points/%s,%s", API, lat, lon)
-	if pointsCache[endpoint] != nil {
-		return pointsCache[endpoint], nil
+func (c *Client) Points(lat string, lon string) (points *PointsResponse, err error) {
+	return c.PointsContext(context.Background(), lat, lon)
+}
+
+// PointsContext is Points with a context that cancels the request.
+// Invalid coordinates and points outside of ServiceAreas fail without a request,
+// with ErrInvalidCoordinates or ErrOutOfCoverage.
+func (c *Client) PointsContext(ctx context.Context, lat string, lon string) (points *PointsResponse, err error) {
+	point, err := ParseLatLon(lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := point.ServiceArea(); !ok && !c.SkipServiceAreaCheck {
+		return nil, fmt.Errorf("%w: %s", ErrOutOfCoverage, point)
 	}
-	res, err := apiCall(endpoint)
+	// the canonical coordinates avoid a redirect and are the cache key
+	endpoint := fmt.Sprintf("%s/points/%s", c.baseURL(), point)
+	if c.Cache != nil {
+		if cached, ok := c.Cache.Get(endpoint); ok {
+			return cached, nil
+		}
+	}
+	res, err := c.apiCall(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -162,17 +144,40 @@ func Points(lat string, lon string) (points *PointsResponse, err error) {
 	if err = decoder.Decode(&points); err != nil {
 		return nil, err
 	}
-	pointsCache[endpoint] = points
+	if c.Cache != nil {
+		c.Cache.Set(endpoint, points, cacheTTL(res.Header))
+	}
 	return points, nil
 }
 
-// Stations returns an array of observation station IDs (urls)
-func Stations(lat string, lon string) (stations *StationsResponse, err error) {
-	point, err := Points(lat, lon)
+// PointsFloat is Points with numeric coordinates
+func (c *Client) PointsFloat(lat float64, lon float64) (*PointsResponse, error) {
+	return c.PointsContext(context.Background(), formatFloat(lat), formatFloat(lon))
+}
+
+// PointsFloatContext is PointsFloat with a context that cancels the request
+func (c *Client) PointsFloatContext(ctx context.Context, lat float64, lon float64) (*PointsResponse, error) {
+	return c.PointsContext(ctx, formatFloat(lat), formatFloat(lon))
+}
+
+// formatFloat formats a coordinate for the string API, it is rounded by ParseLatLon
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Stations returns an array of observation station IDs (urls) and
+// their metadata, nearest first
+func (c *Client) Stations(lat string, lon string) (stations *StationsResponse, err error) {
+	return c.StationsContext(context.Background(), lat, lon)
+}
+
+// StationsContext is Stations with a context that cancels the requests
+func (c *Client) StationsContext(ctx context.Context, lat string, lon string) (stations *StationsResponse, err error) {
+	point, err := c.PointsContext(ctx, lat, lon)
 	if err != nil {
 		return nil, err
 	}
-	res, err := apiCall(point.EndpointObservationStations)
+	res, err := c.apiCall(ctx, point.EndpointObservationStations)
 	if err != nil {
 		return nil, err
 	}
@@ -182,16 +187,60 @@ func Stations(lat string, lon string) (stations *StationsResponse, err error) {
 	if err = decoder.Decode(&stations); err != nil {
 		return nil, err
 	}
+	if point, err := parseLatLon(lat, lon); err == nil {
+		stations.sortByDistance(point)
+	}
 	return stations, nil
 }
 
 // Forecast returns an array of forecast observations (14 periods and 2/day max)
-func Forecast(lat string, lon string) (forecast *ForecastResponse, err error) {
-	point, err := Points(lat, lon)
+func (c *Client) Forecast(lat string, lon string) (forecast *ForecastResponse, err error) {
+	return c.ForecastContext(context.Background(), lat, lon)
+}
+
+// ForecastContext is Forecast with a context that cancels the requests
+func (c *Client) ForecastContext(ctx context.Context, lat string, lon string) (forecast *ForecastResponse, err error) {
+	point, err := c.PointsContext(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	return c.forecastPeriods(ctx, point, point.EndpointForecast)
+}
+
+// ForecastFloat is Forecast with numeric coordinates
+func (c *Client) ForecastFloat(lat float64, lon float64) (*ForecastResponse, error) {
+	return c.ForecastContext(context.Background(), formatFloat(lat), formatFloat(lon))
+}
+
+// ForecastFloatContext is ForecastFloat with a context that cancels the requests
+func (c *Client) ForecastFloatContext(ctx context.Context, lat float64, lon float64) (*ForecastResponse, error) {
+	return c.ForecastContext(ctx, formatFloat(lat), formatFloat(lon))
+}
+
+// ForecastHourlyPeriods returns the hourly text forecast periods (156 hours)
+func (c *Client) ForecastHourlyPeriods(lat string, lon string) (forecast *ForecastResponse, err error) {
+	return c.ForecastHourlyPeriodsContext(context.Background(), lat, lon)
+}
+
+// ForecastHourlyPeriodsContext is ForecastHourlyPeriods with a context that cancels the requests
+func (c *Client) ForecastHourlyPeriodsContext(ctx context.Context, lat string, lon string) (forecast *ForecastResponse, err error) {
+	point, err := c.PointsContext(ctx, lat, lon)
 	if err != nil {
 		return nil, err
 	}
-	res, err := apiCall(point.EndpointForecast)
+	return c.forecastPeriods(ctx, point, point.EndpointForecastHourly)
+}
+
+// forecastPeriods fetches the forecast or hourly forecast endpoint of point
+func (c *Client) forecastPeriods(ctx context.Context, point *PointsResponse, endpoint string) (forecast *ForecastResponse, err error) {
+	switch c.Units {
+	case "":
+	case UnitsUS, UnitsSI:
+		endpoint += "?units=" + string(c.Units)
+	default:
+		return nil, fmt.Errorf("unknown forecast units %q", c.Units)
+	}
+	res, err := c.apiCall(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -201,34 +250,222 @@ func Forecast(lat string, lon string) (forecast *ForecastResponse, err error) {
 	if err = decoder.Decode(&forecast); err != nil {
 		return nil, err
 	}
+	forecast.normalize()
 	forecast.Point = point
 	return forecast, nil
 }
 
 // ForecastDetailed returns a set of timeseries in ForecastGridResponse
-func ForecastDetailed(lat string, lon string) (*ForecastGridResponse, error) {
-	point, err := Points(lat, lon)
+func (c *Client) ForecastDetailed(lat string, lon string) (*ForecastGridResponse, error) {
+	return c.ForecastDetailedContext(context.Background(), lat, lon)
+}
+
+// ForecastDetailedContext is ForecastDetailed with a context that cancels the requests
+func (c *Client) ForecastDetailedContext(ctx context.Context, lat string, lon string) (*ForecastGridResponse, error) {
+	point, err := c.PointsContext(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	forecast, err := c.GetEndpointGridForecastContext(ctx, point.EndpointForecasGrid)
 	if err != nil {
 		return nil, err
 	}
-	return GetEndpointGridForecast(point.EndpointForecasGrid)
+	forecast.Timezone = point.Timezone
+	return forecast, nil
+}
+
+// ForecastDetailedFloat is ForecastDetailed with numeric coordinates
+func (c *Client) ForecastDetailedFloat(lat float64, lon float64) (*ForecastGridResponse, error) {
+	return c.ForecastDetailedContext(context.Background(), formatFloat(lat), formatFloat(lon))
+}
+
+// ForecastDetailedFloatContext is ForecastDetailedFloat with a context that cancels the requests
+func (c *Client) ForecastDetailedFloatContext(ctx context.Context, lat float64, lon float64) (*ForecastGridResponse, error) {
+	return c.ForecastDetailedContext(ctx, formatFloat(lat), formatFloat(lon))
 }
 
 // GetEndpointGridForecast returns the forecast for an endpoint
-func GetEndpointGridForecast(endpoint string) (*ForecastGridResponse, error) {
-	res, err := apiCall(endpoint)
+func (c *Client) GetEndpointGridForecast(endpoint string) (*ForecastGridResponse, error) {
+	return c.GetEndpointGridForecastContext(context.Background(), endpoint)
+}
+
+// GetEndpointGridForecastContext is GetEndpointGridForecast with a context that cancels the request.
+// When c.GridCache holds the endpoint the request is conditional and
+// the cached response is decoded again if it was not modified since.
+func (c *Client) GetEndpointGridForecastContext(ctx context.Context, endpoint string) (*ForecastGridResponse, error) {
+	cached, _ := c.GridCache.get(endpoint)
+	res, err := c.apiCallHeader(ctx, endpoint, cached.header())
 	if err != nil {
 		return nil, err
 	}
 	defer res.Body.Close()
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
+	var body []byte
+	if res.StatusCode == http.StatusNotModified {
+		c.logf("not modified %s", endpoint)
+		body = cached.value.([]byte)
+	} else {
+		body, err = ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
 	}
+	// decode the body on every call so callers never share a forecast
 	var forecast ForecastGridResponse
 	if err = json.Unmarshal(body, &forecast); err != nil {
 		return nil, err
 	}
 	forecast.ID = endpoint
+	if res.StatusCode != http.StatusNotModified {
+		c.GridCache.set(endpoint, res.Header, body)
+	}
 	return &forecast, nil
 }
+
+// GetEndpointGridForecasts fetches the forecasts for many endpoints concurrently,
+// e.g. as input to AverageForecast. At most c.Concurrency requests are in flight
+// and they are throttled by c.RateLimiter.
+func (c *Client) GetEndpointGridForecasts(endpoints []string) ([]*ForecastGridResponse, error) {
+	return c.GetEndpointGridForecastsContext(context.Background(), endpoints)
+}
+
+// GetEndpointGridForecastsContext is GetEndpointGridForecasts with a context that cancels the requests
+func (c *Client) GetEndpointGridForecastsContext(ctx context.Context, endpoints []string) ([]*ForecastGridResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	forecasts := make([]*ForecastGridResponse, len(endpoints))
+	jobs := make(chan int)
+	errs := make(chan error, len(endpoints))
+	workers := c.concurrency()
+	if workers > len(endpoints) {
+		workers = len(endpoints)
+	}
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range jobs {
+				fcst, err := c.GetEndpointGridForecastContext(ctx, endpoints[i])
+				forecasts[i] = fcst
+				errs <- err
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := range endpoints {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				// report the remaining endpoints as canceled
+				for ; i < len(endpoints); i++ {
+					errs <- ctx.Err()
+				}
+				return
+			}
+		}
+	}()
+	var firstErr error
+	for range endpoints {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return forecasts, nil
+}
+
+// Points calls Client.Points on the DefaultClient
+func Points(lat string, lon string) (points *PointsResponse, err error) {
+	return DefaultClient.Points(lat, lon)
+}
+
+// PointsContext calls Client.PointsContext on the DefaultClient
+func PointsContext(ctx context.Context, lat string, lon string) (points *PointsResponse, err error) {
+	return DefaultClient.PointsContext(ctx, lat, lon)
+}
+
+// PointsFloat calls Client.PointsFloat on the DefaultClient
+func PointsFloat(lat float64, lon float64) (*PointsResponse, error) {
+	return DefaultClient.PointsFloat(lat, lon)
+}
+
+// PointsFloatContext calls Client.PointsFloatContext on the DefaultClient
+func PointsFloatContext(ctx context.Context, lat float64, lon float64) (*PointsResponse, error) {
+	return DefaultClient.PointsFloatContext(ctx, lat, lon)
+}
+
+// Stations calls Client.Stations on the DefaultClient
+func Stations(lat string, lon string) (stations *StationsResponse, err error) {
+	return DefaultClient.Stations(lat, lon)
+}
+
+// StationsContext calls Client.StationsContext on the DefaultClient
+func StationsContext(ctx context.Context, lat string, lon string) (stations *StationsResponse, err error) {
+	return DefaultClient.StationsContext(ctx, lat, lon)
+}
+
+// Forecast calls Client.Forecast on the DefaultClient
+func Forecast(lat string, lon string) (forecast *ForecastResponse, err error) {
+	return DefaultClient.Forecast(lat, lon)
+}
+
+// ForecastContext calls Client.ForecastContext on the DefaultClient
+func ForecastContext(ctx context.Context, lat string, lon string) (forecast *ForecastResponse, err error) {
+	return DefaultClient.ForecastContext(ctx, lat, lon)
+}
+
+// ForecastFloat calls Client.ForecastFloat on the DefaultClient
+func ForecastFloat(lat float64, lon float64) (*ForecastResponse, error) {
+	return DefaultClient.ForecastFloat(lat, lon)
+}
+
+// ForecastFloatContext calls Client.ForecastFloatContext on the DefaultClient
+func ForecastFloatContext(ctx context.Context, lat float64, lon float64) (*ForecastResponse, error) {
+	return DefaultClient.ForecastFloatContext(ctx, lat, lon)
+}
+
+// ForecastHourlyPeriods calls Client.ForecastHourlyPeriods on the DefaultClient
+func ForecastHourlyPeriods(lat string, lon string) (forecast *ForecastResponse, err error) {
+	return DefaultClient.ForecastHourlyPeriods(lat, lon)
+}
+
+// ForecastHourlyPeriodsContext calls Client.ForecastHourlyPeriodsContext on the DefaultClient
+func ForecastHourlyPeriodsContext(ctx context.Context, lat string, lon string) (forecast *ForecastResponse, err error) {
+	return DefaultClient.ForecastHourlyPeriodsContext(ctx, lat, lon)
+}
+
+// ForecastDetailed calls Client.ForecastDetailed on the DefaultClient
+func ForecastDetailed(lat string, lon string) (*ForecastGridResponse, error) {
+	return DefaultClient.ForecastDetailed(lat, lon)
+}
+
+// ForecastDetailedContext calls Client.ForecastDetailedContext on the DefaultClient
+func ForecastDetailedContext(ctx context.Context, lat string, lon string) (*ForecastGridResponse, error) {
+	return DefaultClient.ForecastDetailedContext(ctx, lat, lon)
+}
+
+// ForecastDetailedFloat calls Client.ForecastDetailedFloat on the DefaultClient
+func ForecastDetailedFloat(lat float64, lon float64) (*ForecastGridResponse, error) {
+	return DefaultClient.ForecastDetailedFloat(lat, lon)
+}
+
+// ForecastDetailedFloatContext calls Client.ForecastDetailedFloatContext on the DefaultClient
+func ForecastDetailedFloatContext(ctx context.Context, lat float64, lon float64) (*ForecastGridResponse, error) {
+	return DefaultClient.ForecastDetailedFloatContext(ctx, lat, lon)
+}
+
+// GetEndpointGridForecast calls Client.GetEndpointGridForecast on the DefaultClient
+func GetEndpointGridForecast(endpoint string) (*ForecastGridResponse, error) {
+	return DefaultClient.GetEndpointGridForecast(endpoint)
+}
+
+// GetEndpointGridForecastContext calls Client.GetEndpointGridForecastContext on the DefaultClient
+func GetEndpointGridForecastContext(ctx context.Context, endpoint string) (*ForecastGridResponse, error) {
+	return DefaultClient.GetEndpointGridForecastContext(ctx, endpoint)
+}
+
+// GetEndpointGridForecasts calls Client.GetEndpointGridForecasts on the DefaultClient
+func GetEndpointGridForecasts(endpoints []string) ([]*ForecastGridResponse, error) {
+	return DefaultClient.GetEndpointGridForecasts(endpoints)
+}
@@ -10,7 +10,7 @@ func TestBlank(t *testing.T) {
 	if point == nil && err != nil {
 		return
 	}
-	t.Error("noaa.Points() should return a 404 error for a blank lat, lon.")
+	t.Error("noaa.Points() should return an error for a blank lat, lon.")
 }
 
 func TestBlankLat(t *testing.T) {
@@ -18,7 +18,7 @@ func TestBlankLat(t *testing.T) {
 	if point == nil && err != nil {
 		return
 	}
-	t.Error("noaa.Points() should return a 404 error for a blank lat.")
+	t.Error("noaa.Points() should return an error for a blank lat.")
 }
 
 func TestBlankLon(t *testing.T) {
@@ -26,7 +26,7 @@ func TestBlankLon(t *testing.T) {
 	if point == nil && err != nil {
 		return
 	}
-	t.Error("noaa.Points() should return a 404 error for a blank lon.")
+	t.Error("noaa.Points() should return an error for a blank lon.")
 }
 
 func TestZero(t *testing.T) {
@@ -34,7 +34,7 @@ func TestZero(t *testing.T) {
 	if point == nil && err != nil {
 		return
 	}
-	t.Error("noaa.Points() should return a 404 error for a zero lat, lon.")
+	t.Error("noaa.Points() should return an error for a zero lat, lon.")
 }
 
 func TestInternational(t *testing.T) {
@@ -42,7 +42,7 @@ func TestInternational(t *testing.T) {
 	if point == nil && err != nil {
 		return
 	}
-	t.Error("noaa.Points() should return a 404 error for lat, lon outside the U.S. territories.")
+	t.Error("noaa.Points() should return an error for lat, lon outside the U.S. territories.")
 }
 
 func TestAlaska(t *testing.T) {
@@ -59,12 +59,12 @@ func TestForecastDuration(t *testing.T) {
 	testCases["2019-10-27T09:00:00+00:00/P1DT15H"] = time.Duration((24 + 15) * 3600 * 1e9)
 	testCases["2019-10-29T06:00:00+00:00/P5D"] = time.Duration((24 * 5) * 3600 * 1e9)
 	for ts, durExpected := range testCases {
-		durParsed, err := parseDuration(ts)
+		parsed, err := ParseInterval(ts)
 		if err != nil {
 			t.Error(err)
 		}
-		if *durParsed != durExpected {
-			t.Errorf("computed duration %s doesn't match expected %s", durParsed, durExpected)
+		if parsed.Duration != durExpected {
+			t.Errorf("computed duration %s doesn't match expected %s", parsed.Duration, durExpected)
 		}
 	}
 }
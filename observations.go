@@ -0,0 +1,230 @@
+package noaa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/adamgreenhall/noaa/units"
+)
+
+// Quality control flags of a QuantitativeValue, from the MADIS quality control checks
+const (
+	QCPreliminary    = "Z"
+	QCCoarsePass     = "C"
+	QCScreened       = "S"
+	QCVerified       = "V"
+	QCRejected       = "X"
+	QCQuestioned     = "Q"
+	QCSubjectiveGood = "G"
+	QCSubjectiveBad  = "B"
+	QCTemporalPassed = "T"
+)
+
+// Usable is true when the value is present and was not rejected or questioned by quality control
+func (q QuantitativeValue) Usable() bool {
+	switch q.QualityControl {
+	case QCRejected, QCQuestioned, QCSubjectiveBad:
+		return false
+	}
+	return q.Value != nil
+}
+
+// Convert returns the value in the unit code, e.g. "unit:degF". Missing values stay missing.
+func (q QuantitativeValue) Convert(code string) (QuantitativeValue, error) {
+	out := q
+	to, err := units.Parse(code)
+	if err != nil {
+		return q, err
+	}
+	out.UnitCode = to.String()
+	if q.Value == nil {
+		return out, nil
+	}
+	value, err := units.ConvertCode(*q.Value, q.UnitCode, code)
+	if err != nil {
+		return q, err
+	}
+	out.Value = &value
+	return out, nil
+}
+
+// CloudLayer is a cloud base height and its METAR sky cover, e.g. "FEW", "BKN" or "OVC"
+type CloudLayer struct {
+	Base   QuantitativeValue `json:"base"`
+	Amount string            `json:"amount"`
+}
+
+// Observation holds the JSON values from /stations/<id>/observations/<time>
+type Observation struct {
+	ID              string    `json:"@id"`
+	Station         string    `json:"station"`
+	Timestamp       time.Time `json:"timestamp"`
+	RawMessage      string    `json:"rawMessage"`
+	TextDescription string    `json:"textDescription"`
+	Icon            string    `json:"icon"`
+
+	Elevation                 QuantitativeValue `json:"elevation"`
+	Temperature               QuantitativeValue `json:"temperature"`
+	Dewpoint                  QuantitativeValue `json:"dewpoint"`
+	WindDirection             QuantitativeValue `json:"windDirection"`
+	WindSpeed                 QuantitativeValue `json:"windSpeed"`
+	WindGust                  QuantitativeValue `json:"windGust"`
+	BarometricPressure        QuantitativeValue `json:"barometricPressure"`
+	SeaLevelPressure          QuantitativeValue `json:"seaLevelPressure"`
+	Visibility                QuantitativeValue `json:"visibility"`
+	MaxTemperatureLast24Hours QuantitativeValue `json:"maxTemperatureLast24Hours"`
+	MinTemperatureLast24Hours QuantitativeValue `json:"minTemperatureLast24Hours"`
+	PrecipitationLastHour     QuantitativeValue `json:"precipitationLastHour"`
+	PrecipitationLast3Hours   QuantitativeValue `json:"precipitationLast3Hours"`
+	PrecipitationLast6Hours   QuantitativeValue `json:"precipitationLast6Hours"`
+	RelativeHumidity          QuantitativeValue `json:"relativeHumidity"`
+	WindChill                 QuantitativeValue `json:"windChill"`
+	HeatIndex                 QuantitativeValue `json:"heatIndex"`
+
+	CloudLayers []CloudLayer `json:"cloudLayers"`
+}
+
+// observationValues lists the measurements of an Observation
+var observationValues = []func(o *Observation) *QuantitativeValue{
+	func(o *Observation) *QuantitativeValue { return &o.Elevation },
+	func(o *Observation) *QuantitativeValue { return &o.Temperature },
+	func(o *Observation) *QuantitativeValue { return &o.Dewpoint },
+	func(o *Observation) *QuantitativeValue { return &o.WindDirection },
+	func(o *Observation) *QuantitativeValue { return &o.WindSpeed },
+	func(o *Observation) *QuantitativeValue { return &o.WindGust },
+	func(o *Observation) *QuantitativeValue { return &o.BarometricPressure },
+	func(o *Observation) *QuantitativeValue { return &o.SeaLevelPressure },
+	func(o *Observation) *QuantitativeValue { return &o.Visibility },
+	func(o *Observation) *QuantitativeValue { return &o.MaxTemperatureLast24Hours },
+	func(o *Observation) *QuantitativeValue { return &o.MinTemperatureLast24Hours },
+	func(o *Observation) *QuantitativeValue { return &o.PrecipitationLastHour },
+	func(o *Observation) *QuantitativeValue { return &o.PrecipitationLast3Hours },
+	func(o *Observation) *QuantitativeValue { return &o.PrecipitationLast6Hours },
+	func(o *Observation) *QuantitativeValue { return &o.RelativeHumidity },
+	func(o *Observation) *QuantitativeValue { return &o.WindChill },
+	func(o *Observation) *QuantitativeValue { return &o.HeatIndex },
+}
+
+// ToSystem returns a copy with every measurement in its metric or imperial units
+func (o *Observation) ToSystem(system units.System) (*Observation, error) {
+	out := *o
+	for _, field := range observationValues {
+		q := field(&out)
+		if q.UnitCode == "" {
+			continue
+		}
+		from, err := units.Parse(q.UnitCode)
+		if err != nil {
+			return nil, err
+		}
+		if *q, err = q.Convert(from.In(system).Code); err != nil {
+			return nil, err
+		}
+	}
+	out.CloudLayers = make([]CloudLayer, len(o.CloudLayers))
+	for i, layer := range o.CloudLayers {
+		out.CloudLayers[i] = layer
+		if layer.Base.UnitCode == "" {
+			continue
+		}
+		from, err := units.Parse(layer.Base.UnitCode)
+		if err != nil {
+			return nil, err
+		}
+		if out.CloudLayers[i].Base, err = layer.Base.Convert(from.In(system).Code); err != nil {
+			return nil, err
+		}
+	}
+	return &out, nil
+}
+
+// observationsResponse holds the JSON values from /stations/<id>/observations
+type observationsResponse struct {
+	Observations []*Observation `json:"@graph"`
+}
+
+// stationEndpoint is the /stations/<id> endpoint, stationID may also be a station URL
+func (c *Client) stationEndpoint(stationID string) string {
+	return fmt.Sprintf("%s/stations/%s", c.baseURL(), path.Base(stationID))
+}
+
+// LatestObservation returns the most recent observation of a station, e.g. "KSEA"
+func (c *Client) LatestObservation(stationID string) (*Observation, error) {
+	return c.LatestObservationContext(context.Background(), stationID)
+}
+
+// LatestObservationContext is LatestObservation with a context that cancels the request
+func (c *Client) LatestObservationContext(ctx context.Context, stationID string) (observation *Observation, err error) {
+	res, err := c.apiCall(ctx, c.stationEndpoint(stationID)+"/observations/latest")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	if err = decoder.Decode(&observation); err != nil {
+		return nil, err
+	}
+	return observation, nil
+}
+
+// Observations returns the observations of a station between start and end, newest first.
+// Zero times leave the range open and limit <= 0 uses the API default.
+func (c *Client) Observations(stationID string, start time.Time, end time.Time, limit int) ([]*Observation, error) {
+	return c.ObservationsContext(context.Background(), stationID, start, end, limit)
+}
+
+// ObservationsContext is Observations with a context that cancels the request
+func (c *Client) ObservationsContext(ctx context.Context, stationID string, start time.Time, end time.Time, limit int) ([]*Observation, error) {
+	query := url.Values{}
+	if !start.IsZero() {
+		query.Set("start", start.UTC().Format(timeFormat))
+	}
+	if !end.IsZero() {
+		query.Set("end", end.UTC().Format(timeFormat))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	endpoint := c.stationEndpoint(stationID) + "/observations"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	res, err := c.apiCall(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var observations observationsResponse
+	decoder := json.NewDecoder(res.Body)
+	if err = decoder.Decode(&observations); err != nil {
+		return nil, err
+	}
+	return observations.Observations, nil
+}
+
+// LatestObservation calls Client.LatestObservation on the DefaultClient
+func LatestObservation(stationID string) (*Observation, error) {
+	return DefaultClient.LatestObservation(stationID)
+}
+
+// LatestObservationContext calls Client.LatestObservationContext on the DefaultClient
+func LatestObservationContext(ctx context.Context, stationID string) (*Observation, error) {
+	return DefaultClient.LatestObservationContext(ctx, stationID)
+}
+
+// Observations calls Client.Observations on the DefaultClient
+func Observations(stationID string, start time.Time, end time.Time, limit int) ([]*Observation, error) {
+	return DefaultClient.Observations(stationID, start, end, limit)
+}
+
+// ObservationsContext calls Client.ObservationsContext on the DefaultClient
+func ObservationsContext(ctx context.Context, stationID string, start time.Time, end time.Time, limit int) ([]*Observation, error) {
+	return DefaultClient.ObservationsContext(ctx, stationID, start, end, limit)
+}
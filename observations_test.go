@@ -0,0 +1,97 @@
+package noaa
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adamgreenhall/noaa/units"
+	"github.com/stretchr/testify/assert"
+)
+
+const testObservation = `{
+	"@id": "https://api.weather.gov/stations/KSEA/observations/2019-10-27T16:53:00+00:00",
+	"station": "https://api.weather.gov/stations/KSEA",
+	"timestamp": "2019-10-27T16:53:00+00:00",
+	"rawMessage": "KSEA 271653Z 01008KT 10SM FEW250 M01/M09 A3052",
+	"textDescription": "Mostly Clear",
+	"elevation": {"value": 136, "unitCode": "wmoUnit:m"},
+	"temperature": {"value": -0.6, "unitCode": "wmoUnit:degC", "qualityControl": "V"},
+	"dewpoint": {"value": -8.9, "unitCode": "wmoUnit:degC", "qualityControl": "V"},
+	"windDirection": {"value": 10, "unitCode": "wmoUnit:degree_(angle)", "qualityControl": "V"},
+	"windSpeed": {"value": 14.8, "unitCode": "wmoUnit:km_h-1", "qualityControl": "V"},
+	"windGust": {"value": null, "unitCode": "wmoUnit:km_h-1", "qualityControl": "Z"},
+	"barometricPressure": {"value": 103360, "unitCode": "wmoUnit:Pa", "qualityControl": "V"},
+	"visibility": {"value": 16090, "unitCode": "wmoUnit:m", "qualityControl": "C"},
+	"precipitationLastHour": {"value": null, "unitCode": "wmoUnit:mm", "qualityControl": "Z"},
+	"precipitationLast6Hours": {"value": 2.5, "unitCode": "wmoUnit:mm", "qualityControl": "X"},
+	"relativeHumidity": {"value": 53.4, "unitCode": "wmoUnit:percent", "qualityControl": "V"},
+	"cloudLayers": [{"base": {"value": 7620, "unitCode": "wmoUnit:m"}, "amount": "FEW"}]
+}`
+
+func newObservationServer(queries *[]string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stations/KSEA/observations/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testObservation)
+	})
+	mux.HandleFunc("/stations/KSEA/observations", func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+		fmt.Fprintf(w, `{"@graph": [%s, %s]}`, testObservation, testObservation)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestLatestObservation(t *testing.T) {
+	server := newObservationServer(&[]string{})
+	defer server.Close()
+	client := newTestClient(server)
+
+	obs, err := client.LatestObservation("KSEA")
+	check(err)
+	assert.Equal(t, time.Date(2019, 10, 27, 16, 53, 0, 0, time.UTC), obs.Timestamp.UTC())
+	assert.Equal(t, "Mostly Clear", obs.TextDescription)
+	assert.Equal(t, -0.6, *obs.Temperature.Value)
+	assert.Equal(t, QCVerified, obs.Temperature.QualityControl)
+	assert.True(t, obs.Temperature.Usable())
+	assert.Nil(t, obs.WindGust.Value)
+	assert.False(t, obs.WindGust.Usable())
+	assert.False(t, obs.PrecipitationLast6Hours.Usable())
+	assert.Nil(t, obs.PrecipitationLast3Hours.Value)
+	assert.Equal(t, []CloudLayer{{Base: obs.CloudLayers[0].Base, Amount: "FEW"}}, obs.CloudLayers)
+
+	imperial, err := obs.ToSystem(units.Imperial)
+	check(err)
+	assert.InDelta(t, 30.92, *imperial.Temperature.Value, 0.01)
+	assert.Equal(t, "unit:degF", imperial.Temperature.UnitCode)
+	assert.Equal(t, QCVerified, imperial.Temperature.QualityControl)
+	assert.InDelta(t, 9.2, *imperial.WindSpeed.Value, 0.01)
+	assert.InDelta(t, 30.52, *imperial.BarometricPressure.Value, 0.01)
+	assert.InDelta(t, 25000, *imperial.CloudLayers[0].Base.Value, 1)
+	assert.Nil(t, imperial.WindGust.Value)
+	assert.Equal(t, "unit:mi_h-1", imperial.WindGust.UnitCode)
+	// the original is left unchanged
+	assert.Equal(t, -0.6, *obs.Temperature.Value)
+	assert.Equal(t, 7620.0, *obs.CloudLayers[0].Base.Value)
+}
+
+func TestObservations(t *testing.T) {
+	queries := []string{}
+	server := newObservationServer(&queries)
+	defer server.Close()
+	client := newTestClient(server)
+
+	start := time.Date(2019, 10, 27, 0, 0, 0, 0, time.UTC)
+	observations, err := client.Observations(server.URL+"/stations/KSEA", start, start.Add(24*time.Hour), 2)
+	check(err)
+	assert.Equal(t, 2, len(observations))
+	assert.Equal(t, 103360.0, *observations[1].BarometricPressure.Value)
+
+	_, err = client.Observations("KSEA", time.Time{}, time.Time{}, 0)
+	check(err)
+	assert.Equal(t, []string{"end=2019-10-28T00%3A00%3A00Z&limit=2&start=2019-10-27T00%3A00%3A00Z", ""}, queries)
+
+	_, err = client.LatestObservation("KXYZ")
+	assert.True(t, IsNotFound(err))
+}
@@ -0,0 +1,162 @@
+package noaa
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adamgreenhall/noaa/units"
+)
+
+// IconCondition is one condition of a forecast icon, e.g. "rain_showers" with a 40% chance
+type IconCondition struct {
+	Code string
+	// Probability in percent, 0 when the icon does not give one
+	Probability int
+}
+
+// ForecastIcon is the decomposed icon URL of a ForecastPeriod,
+// e.g. https://api.weather.gov/icons/land/night/rain_showers,40/rain,60?size=medium
+type ForecastIcon struct {
+	// Set is "land" or "marine"
+	Set     string
+	Daytime bool
+	// Conditions holds one or two conditions, the second one for later in the period
+	Conditions []IconCondition
+}
+
+// compassPoints are the 16 compass directions clockwise from north
+var compassPoints = []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// UnmarshalJSON decodes the period and parses its text fields
+func (p *ForecastPeriod) UnmarshalJSON(buf []byte) error {
+	type period ForecastPeriod
+	if err := json.Unmarshal(buf, (*period)(p)); err != nil {
+		return err
+	}
+	var err error
+	if p.StartTime != "" {
+		if p.Start, err = parseISOTime(p.StartTime); err != nil {
+			return err
+		}
+	}
+	if p.EndTime != "" {
+		if p.End, err = parseISOTime(p.EndTime); err != nil {
+			return err
+		}
+	}
+	p.TemperatureValue = QuantitativeValue{}
+	if unit, err := units.Parse(p.TemperatureUnit); err == nil && unit.Dimension == units.Temperature {
+		temperature := p.Temperature
+		p.TemperatureValue = QuantitativeValue{Value: &temperature, UnitCode: unit.String()}
+	}
+	p.DewpointValue = p.Dewpoint
+	p.WindSpeedMin, p.WindSpeedMax = parseWindSpeed(p.WindSpeed)
+	p.WindDirectionDegrees = parseCompassPoint(p.WindDirection)
+	p.Conditions = parseForecastIcon(p.Icon)
+	return nil
+}
+
+// parseWindSpeed parses "5 mph" or "10 to 15 mph", values are missing when the text is not understood
+func parseWindSpeed(s string) (QuantitativeValue, QuantitativeValue) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 && !(len(fields) == 4 && fields[1] == "to") {
+		return QuantitativeValue{}, QuantitativeValue{}
+	}
+	unit, err := units.Parse(fields[len(fields)-1])
+	if err != nil || unit.Dimension != units.Speed {
+		return QuantitativeValue{}, QuantitativeValue{}
+	}
+	speeds := make([]float64, 0, 2)
+	for _, field := range []string{fields[0], fields[len(fields)-2]} {
+		speed, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return QuantitativeValue{}, QuantitativeValue{}
+		}
+		speeds = append(speeds, speed)
+	}
+	return QuantitativeValue{Value: &speeds[0], UnitCode: unit.String()},
+		QuantitativeValue{Value: &speeds[1], UnitCode: unit.String()}
+}
+
+// parseCompassPoint returns the bearing of a compass point like "NNW", nil when unknown
+func parseCompassPoint(s string) *float64 {
+	for i, point := range compassPoints {
+		if strings.EqualFold(s, point) {
+			degrees := float64(i) * 360 / float64(len(compassPoints))
+			return &degrees
+		}
+	}
+	return nil
+}
+
+// parseForecastIcon decomposes an icon URL, the zero value when it does not look like one
+func parseForecastIcon(s string) ForecastIcon {
+	u, err := url.Parse(s)
+	if err != nil {
+		return ForecastIcon{}
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, part := range parts {
+		if part != "icons" || i+3 > len(parts) {
+			continue
+		}
+		icon := ForecastIcon{
+			Set:     parts[i+1],
+			Daytime: parts[i+2] == "day",
+		}
+		for _, condition := range parts[i+3:] {
+			code := condition
+			probability := 0
+			if comma := strings.Index(code, ","); comma >= 0 {
+				probability, _ = strconv.Atoi(code[comma+1:])
+				code = code[:comma]
+			}
+			icon.Conditions = append(icon.Conditions, IconCondition{Code: code, Probability: probability})
+		}
+		return icon
+	}
+	return ForecastIcon{}
+}
+
+// Duration is the length of the period
+func (p *ForecastPeriod) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+func (c IconCondition) String() string {
+	if c.Probability == 0 {
+		return c.Code
+	}
+	return fmt.Sprintf("%s,%d", c.Code, c.Probability)
+}
+
+// system is the unit system of the forecast, metric for UnitsSI
+func (f *ForecastResponse) system() units.System {
+	if ForecastUnits(f.Units) == UnitsSI {
+		return units.Metric
+	}
+	return units.Imperial
+}
+
+// normalize converts the parsed temperatures, dewpoints and wind speeds to the
+// units of the forecast. Values with an unknown unit are left as sent and the
+// raw API fields, e.g. Dewpoint, are never changed.
+func (f *ForecastResponse) normalize() {
+	system := f.system()
+	for i := range f.Periods {
+		p := &f.Periods[i]
+		for _, q := range []*QuantitativeValue{&p.TemperatureValue, &p.DewpointValue, &p.WindSpeedMin, &p.WindSpeedMax} {
+			unit, err := units.Parse(q.UnitCode)
+			if err != nil {
+				continue
+			}
+			if converted, err := q.Convert(unit.In(system).Code); err == nil {
+				*q = converted
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package noaa
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastPeriodParsing(t *testing.T) {
+	buf, err := ioutil.ReadFile("test_cases/forecast.json")
+	check(err)
+	var forecast ForecastResponse
+	check(json.Unmarshal(buf, &forecast))
+	today, tonight := forecast.Periods[0], forecast.Periods[1]
+
+	assert.Equal(t, time.Date(2019, 10, 27, 16, 0, 0, 0, time.UTC), today.Start.UTC())
+	assert.Equal(t, 9*time.Hour, today.Duration())
+	assert.Equal(t, 5.0, *today.WindSpeedMin.Value)
+	assert.Equal(t, 10.0, *today.WindSpeedMax.Value)
+	assert.Equal(t, "unit:mi_h-1", today.WindSpeedMax.UnitCode)
+	assert.Equal(t, 337.5, *today.WindDirectionDegrees)
+	assert.Equal(t, ForecastIcon{Set: "land", Daytime: true, Conditions: []IconCondition{{Code: "few"}}}, today.Conditions)
+	assert.Nil(t, today.ProbabilityOfPrecipitation.Value)
+	assert.Equal(t, 45.0, *today.RelativeHumidity.Value)
+
+	assert.Equal(t, 15.0, *tonight.WindSpeedMin.Value)
+	assert.Equal(t, 15.0, *tonight.WindSpeedMax.Value)
+	assert.Nil(t, tonight.WindDirectionDegrees)
+	assert.False(t, tonight.Conditions.Daytime)
+	assert.Equal(t, []IconCondition{{"rain_showers", 40}, {"rain", 60}}, tonight.Conditions.Conditions)
+	assert.Equal(t, "rain,60", tonight.Conditions.Conditions[1].String())
+	assert.Equal(t, 60.0, *tonight.ProbabilityOfPrecipitation.Value)
+}
+
+func TestParseWindSpeed(t *testing.T) {
+	low, high := parseWindSpeed("10 to 20 km/h")
+	assert.Equal(t, 10.0, *low.Value)
+	assert.Equal(t, 20.0, *high.Value)
+	assert.Equal(t, "unit:km_h-1", high.UnitCode)
+
+	for _, s := range []string{"", "Calm", "10 to mph", "5 F", "five mph", "10 20 30 mph"} {
+		low, high := parseWindSpeed(s)
+		assert.Nil(t, low.Value, s)
+		assert.Nil(t, high.Value, s)
+	}
+
+	assert.Equal(t, 0.0, *parseCompassPoint("N"))
+	assert.Equal(t, 90.0, *parseCompassPoint("e"))
+	assert.Equal(t, 202.5, *parseCompassPoint("SSW"))
+	assert.Nil(t, parseCompassPoint("VRB"))
+
+	assert.Equal(t, ForecastIcon{}, parseForecastIcon(""))
+	assert.Equal(t, "marine", parseForecastIcon("https://api.weather.gov/icons/marine/day/wind_few").Set)
+}
+
+func TestForecastUnits(t *testing.T) {
+	server := newTestServer(map[string]int{})
+	defer server.Close()
+	client := newTestClient(server)
+
+	us, err := client.Forecast("47.6", "-122.3")
+	check(err)
+	assert.Equal(t, "us", us.Units)
+	assert.Equal(t, 44.0, *us.Periods[0].TemperatureValue.Value)
+	assert.Equal(t, "unit:degF", us.Periods[0].TemperatureValue.UnitCode)
+
+	si, err := client.WithUnits(UnitsSI).Forecast("47.6", "-122.3")
+	check(err)
+	assert.Equal(t, "si", si.Units)
+	assert.Equal(t, "C", si.Periods[0].TemperatureUnit)
+	assert.Equal(t, 7.0, *si.Periods[0].TemperatureValue.Value)
+	assert.Equal(t, "unit:degC", si.Periods[0].TemperatureValue.UnitCode)
+	assert.Equal(t, 16.0, *si.Periods[0].WindSpeedMax.Value)
+	assert.Equal(t, "unit:km_h-1", si.Periods[0].WindSpeedMax.UnitCode)
+	// the original client is unchanged
+	assert.Equal(t, ForecastUnits(""), client.Units)
+
+	client.Units = "metric"
+	_, err = client.Forecast("47.6", "-122.3")
+	assert.Error(t, err)
+}
+
+func TestForecastNormalize(t *testing.T) {
+	var forecast ForecastResponse
+	check(json.Unmarshal([]byte(`{"units": "si", "periods": [
+		{"temperature": 50, "temperatureUnit": "F", "windSpeed": "10 to 20 kt",
+		 "dewpoint": {"unitCode": "wmoUnit:degF", "value": 41},
+		 "relativeHumidity": {"unitCode": "wmoUnit:percent", "value": 70}},
+		{"temperature": 10, "temperatureUnit": "C", "windSpeed": "5 km/h",
+		 "dewpoint": {"unitCode": "wmoUnit:weird", "value": 3}}
+	]}`), &forecast))
+	forecast.normalize()
+	period := forecast.Periods[0]
+	assert.InDelta(t, 10, *period.TemperatureValue.Value, 1e-9)
+	assert.Equal(t, "unit:degC", period.TemperatureValue.UnitCode)
+	assert.InDelta(t, 5, *period.DewpointValue.Value, 1e-9)
+	assert.Equal(t, "unit:degC", period.DewpointValue.UnitCode)
+	assert.InDelta(t, 18.52, *period.WindSpeedMin.Value, 1e-9)
+	assert.InDelta(t, 37.04, *period.WindSpeedMax.Value, 1e-9)
+	assert.Equal(t, "unit:km_h-1", period.WindSpeedMax.UnitCode)
+	// the raw fields are left as sent
+	assert.Equal(t, 50.0, period.Temperature)
+	assert.Equal(t, "10 to 20 kt", period.WindSpeed)
+	assert.Equal(t, 41.0, *period.Dewpoint.Value)
+	assert.Equal(t, "wmoUnit:degF", period.Dewpoint.UnitCode)
+	assert.Equal(t, 70.0, *period.RelativeHumidity.Value)
+
+	// unknown units are not converted
+	period = forecast.Periods[1]
+	assert.Equal(t, 3.0, *period.DewpointValue.Value)
+	assert.Equal(t, "wmoUnit:weird", period.DewpointValue.UnitCode)
+	assert.InDelta(t, 10, *period.TemperatureValue.Value, 1e-9)
+}
@@ -0,0 +1,115 @@
+package noaa
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/adamgreenhall/noaa/units"
+)
+
+// RelativeLocation holds the city nearest to a point from /points/<lat,lon>.
+// Distance and Bearing are measured from the city to the point.
+type RelativeLocation struct {
+	City     string            `json:"city"`
+	State    string            `json:"state"`
+	Geometry string            `json:"geometry"`
+	Distance QuantitativeValue `json:"distance"`
+	Bearing  QuantitativeValue `json:"bearing"`
+	// Location is parsed from Geometry
+	Location LatLon `json:"-"`
+}
+
+// UnmarshalJSON decodes the relative location and parses the city location,
+// Location is left zero when the geometry is missing or cannot be parsed
+func (r *RelativeLocation) UnmarshalJSON(buf []byte) error {
+	type relativeLocation RelativeLocation
+	if err := json.Unmarshal(buf, (*relativeLocation)(r)); err != nil {
+		return err
+	}
+	r.Location, _ = parseWKTPoint(r.Geometry)
+	return nil
+}
+
+// UnmarshalJSON decodes the points response and parses the point location,
+// Location is left zero when the geometry is missing or cannot be parsed
+func (p *PointsResponse) UnmarshalJSON(buf []byte) error {
+	type pointsResponse PointsResponse
+	if err := json.Unmarshal(buf, (*pointsResponse)(p)); err != nil {
+		return err
+	}
+	p.Location, _ = parseWKTPoint(p.Geometry)
+	return nil
+}
+
+// zoneID is the last element of a zone URL, e.g. "WAZ558", empty when the URL is
+func zoneID(url string) string {
+	if url == "" {
+		return ""
+	}
+	return path.Base(url)
+}
+
+// ForecastZoneID is the ID of the forecast zone, e.g. "WAZ558"
+func (p *PointsResponse) ForecastZoneID() string {
+	return zoneID(p.ForecastZone)
+}
+
+// CountyID is the ID of the county zone, e.g. "WAC033"
+func (p *PointsResponse) CountyID() string {
+	return zoneID(p.County)
+}
+
+// FireWeatherZoneID is the ID of the fire weather zone, e.g. "WAZ654"
+func (p *PointsResponse) FireWeatherZoneID() string {
+	return zoneID(p.FireWeatherZone)
+}
+
+// Zones returns the forecast and county zone IDs, e.g. for AlertsQuery.Zones
+func (p *PointsResponse) Zones() []string {
+	zones := make([]string, 0, 2)
+	for _, id := range []string{p.ForecastZoneID(), p.CountyID()} {
+		if id != "" {
+			zones = append(zones, id)
+		}
+	}
+	return zones
+}
+
+// Near labels the point by its nearest city, e.g. "near Seattle, WA",
+// "Seattle, WA" when within a mile, or "" when the city is unknown
+func (p *PointsResponse) Near() string {
+	r := p.RelativeLocation
+	if r.City == "" {
+		return ""
+	}
+	label := r.City
+	if r.State != "" {
+		label += ", " + r.State
+	}
+	if r.Distance.Value != nil {
+		miles, err := units.ConvertCode(*r.Distance.Value, r.Distance.UnitCode, "unit:mi")
+		if err == nil && miles < 1 {
+			return label
+		}
+	}
+	return "near " + label
+}
+
+// Describe is Near with the distance and direction from the city, e.g.
+// "6 mi SSE of Seattle, WA", falling back to Near when they are missing
+func (p *PointsResponse) Describe() string {
+	r := p.RelativeLocation
+	if r.City == "" || r.Distance.Value == nil || r.Bearing.Value == nil {
+		return p.Near()
+	}
+	miles, err := units.ConvertCode(*r.Distance.Value, r.Distance.UnitCode, "unit:mi")
+	if err != nil || miles < 1 {
+		return p.Near()
+	}
+	sector := int(math.Round(math.Mod(*r.Bearing.Value+360, 360)/22.5)) % len(compassPoints)
+	label := strings.TrimSuffix(r.City+", "+r.State, ", ")
+	return fmt.Sprintf("%.0f mi %s of %s", miles, compassPoints[sector], label)
+}
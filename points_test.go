@@ -0,0 +1,72 @@
+package noaa
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointsRelativeLocation(t *testing.T) {
+	server := newTestServer(map[string]int{})
+	defer server.Close()
+	client := newTestClient(server)
+
+	point, err := client.Points("47.6", "-122.3")
+	check(err)
+	assert.Equal(t, LatLon{Lat: 47.6, Lon: -122.3}, point.Location)
+	assert.Equal(t, "Seattle", point.RelativeLocation.City)
+	assert.Equal(t, "WA", point.RelativeLocation.State)
+	assert.Equal(t, LatLon{Lat: 47.603832, Lon: -122.330062}, point.RelativeLocation.Location)
+	assert.Equal(t, 2347.6, *point.RelativeLocation.Distance.Value)
+	assert.Equal(t, "WAZ558", point.ForecastZoneID())
+	assert.Equal(t, "WAC033", point.CountyID())
+	assert.Equal(t, "WAZ654", point.FireWeatherZoneID())
+	assert.Equal(t, []string{"WAZ558", "WAC033"}, point.Zones())
+	assert.Equal(t, "near Seattle, WA", point.Near())
+	assert.Equal(t, "1 mi E of Seattle, WA", point.Describe())
+
+	// the parsed fields survive a cache round trip
+	buf, err := json.Marshal(point)
+	check(err)
+	var loaded PointsResponse
+	check(json.Unmarshal(buf, &loaded))
+	assert.Equal(t, *point, loaded)
+}
+
+func TestPointsNear(t *testing.T) {
+	distance, bearing := 500.0, 350.0
+	point := &PointsResponse{RelativeLocation: RelativeLocation{
+		City:     "Fairbanks",
+		State:    "AK",
+		Distance: QuantitativeValue{Value: &distance, UnitCode: "wmoUnit:m"},
+		Bearing:  QuantitativeValue{Value: &bearing, UnitCode: "wmoUnit:degree_(angle)"},
+	}}
+	assert.Equal(t, "Fairbanks, AK", point.Near())
+	assert.Equal(t, "Fairbanks, AK", point.Describe())
+
+	distance = 16093.44
+	assert.Equal(t, "near Fairbanks, AK", point.Near())
+	assert.Equal(t, "10 mi N of Fairbanks, AK", point.Describe())
+
+	point.RelativeLocation.Bearing.Value = nil
+	assert.Equal(t, "near Fairbanks, AK", point.Describe())
+
+	empty := &PointsResponse{}
+	assert.Equal(t, "", empty.Near())
+	assert.Equal(t, "", empty.Describe())
+	assert.Empty(t, empty.Zones())
+}
+
+func TestPointsBadGeometry(t *testing.T) {
+	var point PointsResponse
+	check(json.Unmarshal([]byte(`{
+		"cwa": "SEW",
+		"geometry": "POINT EMPTY",
+		"relativeLocation": {"city": "Seattle", "state": "WA", "geometry": "POINT(bad)"}
+	}`), &point))
+	assert.Equal(t, "SEW", point.CWA)
+	assert.Equal(t, LatLon{}, point.Location)
+	assert.Equal(t, LatLon{}, point.RelativeLocation.Location)
+	assert.Equal(t, "near Seattle, WA", point.Near())
+}
@@ -0,0 +1,105 @@
+package noaa
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every request of a Client.
+// Tokens are added at Rate per second up to Burst, each request takes one.
+type RateLimiter struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	requests int64
+	waits    int64
+	waited   time.Duration
+}
+
+// RateLimiterStats summarizes the requests that went through a RateLimiter
+type RateLimiterStats struct {
+	// Requests is the number of tokens handed out
+	Requests int64
+	// Waits is the number of requests that had to wait for a token
+	Waits int64
+	// Waited is the total time spent waiting
+	Waited time.Duration
+}
+
+// NewRateLimiter allows rate requests per second on average and bursts of up to burst requests
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait before using it
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	l.requests++
+	if l.tokens >= 0 {
+		return 0
+	}
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.waits++
+	l.waited += wait
+	return wait
+}
+
+// cancel returns a token reserved by a caller that gave up waiting
+func (l *RateLimiter) cancel(wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+	l.requests--
+	l.waits--
+	l.waited -= wait
+}
+
+// Wait blocks until a request is allowed or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+	wait := l.reserve(time.Now())
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel(wait)
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Stats returns the counters accumulated so far
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return RateLimiterStats{
+		Requests: l.requests,
+		Waits:    l.waits,
+		Waited:   l.waited,
+	}
+}
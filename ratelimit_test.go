@@ -0,0 +1,98 @@
+package noaa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := NewRateLimiter(50, 2)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			check(limiter.Wait(context.Background()))
+		}()
+	}
+	wg.Wait()
+	// 2 requests are free, the other 4 are spaced by 20ms
+	assert.True(t, time.Since(start) >= 70*time.Millisecond)
+	stats := limiter.Stats()
+	assert.Equal(t, int64(6), stats.Requests)
+	assert.Equal(t, int64(4), stats.Waits)
+	assert.True(t, stats.Waited >= 150*time.Millisecond)
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	limiter := NewRateLimiter(0.1, 1)
+	check(limiter.Wait(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, limiter.Wait(ctx))
+	assert.Equal(t, int64(1), limiter.Stats().Requests)
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	calls := map[string]int{}
+	server := newTestServer(calls)
+	defer server.Close()
+	client := newTestClient(server)
+	client.RateLimiter = NewRateLimiter(100, 1)
+
+	endpoint := server.URL + "/gridpoints/SEW/151,119"
+	forecasts, err := client.GetEndpointGridForecasts([]string{endpoint, endpoint, endpoint})
+	check(err)
+	assert.Equal(t, 3, len(forecasts))
+	assert.Equal(t, endpoint, forecasts[2].ID)
+	assert.Equal(t, int64(2), client.RateLimiter.Stats().Waits)
+}
+
+func TestClientConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprint(w, `{"validTimes": "2019-10-27T16:00:00+00:00/PT1H"}`)
+	}))
+	defer server.Close()
+	client := newTestClient(server)
+	client.GridCache = nil
+	client.Retry = nil
+
+	endpoints := make([]string, 12)
+	for i := range endpoints {
+		endpoints[i] = fmt.Sprintf("%s/gridpoints/SEW/%d,119", server.URL, i)
+	}
+	forecasts, err := client.GetEndpointGridForecasts(endpoints)
+	check(err)
+	assert.Equal(t, endpoints[11], forecasts[11].ID)
+	assert.Equal(t, DefaultConcurrency, maxInFlight)
+
+	maxInFlight = 0
+	client.Concurrency = 2
+	_, err = client.GetEndpointGridForecasts(endpoints)
+	check(err)
+	assert.Equal(t, 2, maxInFlight)
+
+	// an invalid endpoint cancels the others
+	_, err = client.GetEndpointGridForecasts(append([]string{"http://\x00"}, endpoints...))
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package noaa
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/adamgreenhall/noaa/units"
+)
+
+// ForecastRegular is ForecastHourly for any step, e.g. 15 minutes, 3 hours or a day.
+// Each of Times is the start of a step and the values cover [Times[i], Times[i]+Step).
+type ForecastRegular struct {
+	Step            time.Duration        `json:"step"`
+	CreatedAt       time.Time            `json:"createdAt"`
+	ElevationMeters int64                `json:"elevationMeters"`
+	Endpoint        string               `json:"endpoint"`
+	Timezone        string               `json:"timezone,omitempty"`
+	Times           []time.Time          `json:"times"`
+	SeriesNames     []string             `json:"seriesNames"`
+	Units           []string             `json:"units"`
+	Values          [][]float64          `json:"values"`
+	Weather         [][]WeatherCondition `json:"weather,omitempty"`
+	Hazards         [][]Hazard           `json:"hazards,omitempty"`
+}
+
+// CreateForecastRegular builds a ForecastRegular with the given step from noaa.ForecastGridResponse.
+// The steps cover the valid times of the grid widened to whole steps, each series
+// is resampled with its DefaultResampleMethod. Steps covering several intervals
+// take their time weighted mean, or their sum for totals like precipitation,
+// so intervals straddling a step boundary count in both steps in proportion to their overlap.
+func CreateForecastRegular(grid *ForecastGridResponse, step time.Duration) (*ForecastRegular, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("invalid resampling step %s", step)
+	}
+	validTimes := grid.ValidTimes.Snap(step)
+	return createForecastRegular(grid, validTimes.Time, validTimes.endTime().Add(-step), step, false)
+}
+
+// createForecastRegular resamples every layer of grid onto tMin, tMin+step, ... tMax.
+// snap aligns the intervals to the step first, as done for the hourly series.
+func createForecastRegular(grid *ForecastGridResponse, tMin, tMax time.Time, step time.Duration, snap bool) (*ForecastRegular, error) {
+	resampled := make(map[string]*ForecastTimeseries)
+	for k, ts := range grid.timeseriesMap() {
+		if snap {
+			ts = ts.snapped(step)
+		}
+		ts, err := ts.resample(tMin, tMax, step, DefaultResampleMethod(k))
+		if err != nil {
+			return nil, err
+		}
+		resampled[k] = ts
+	}
+	seriesNames := timeseriesNames(resampled)
+	if len(seriesNames) == 0 {
+		return nil, fmt.Errorf("no timeseries found for %s", grid.ID)
+	}
+	times := make([]time.Time, len(resampled[seriesNames[0]].Values))
+	for i, val := range resampled[seriesNames[0]].Values {
+		times[i] = val.Time.Time
+	}
+	values := make([][]float64, len(seriesNames))
+	seriesUnits := make([]string, len(seriesNames))
+	for i, nm := range seriesNames {
+		ts := resampled[nm]
+		seriesUnits[i] = unitSymbol(ts.Units)
+		values[i] = make([]float64, len(times))
+		for j, val := range ts.Values {
+			values[i][j] = roundSeries(nm, val.Value)
+		}
+	}
+	elevationMeters, err := units.ConvertCode(grid.Elevation.Value, grid.Elevation.Units, "unit:m")
+	if err != nil {
+		return nil, fmt.Errorf("unknown elevation units: %s", grid.Elevation.Units)
+	}
+	var weather [][]WeatherCondition
+	if grid.Weather != nil {
+		weather = grid.Weather.At(times)
+	}
+	var hazards [][]Hazard
+	if grid.Hazards != nil {
+		hazards = grid.Hazards.At(times)
+	}
+	return &ForecastRegular{
+		Step:            step,
+		CreatedAt:       grid.Updated,
+		ElevationMeters: int64(elevationMeters),
+		Endpoint:        grid.ID,
+		Timezone:        grid.Timezone,
+		Times:           times,
+		SeriesNames:     seriesNames,
+		Units:           seriesUnits,
+		Values:          values,
+		Weather:         weather,
+		Hazards:         hazards,
+	}, nil
+}
+
+// roundSeries rounds to one digit precision, except the totals split across
+// steps by ResampleDistribute whose sum must match the grid
+func roundSeries(name string, value float64) float64 {
+	if DefaultResampleMethod(name) == ResampleDistribute {
+		return value
+	}
+	return math.Round(value*10) / 10
+}
+
+// ToSystem returns a copy with every series in its metric or imperial units
+func (r *ForecastRegular) ToSystem(system units.System) (*ForecastRegular, error) {
+	targets, err := systemTargets(r.SeriesNames, r.Units, system)
+	if err != nil {
+		return nil, err
+	}
+	return r.Convert(targets)
+}
+
+// Convert returns a copy with the series named in targets converted to the
+// given unit codes, e.g. {"Temperature": "unit:degF"}
+func (r *ForecastRegular) Convert(targets map[string]string) (*ForecastRegular, error) {
+	seriesUnits, values, err := convertSeries(r.SeriesNames, r.Units, r.Values, targets)
+	if err != nil {
+		return nil, err
+	}
+	out := *r
+	out.Units = seriesUnits
+	out.Values = values
+	return &out, nil
+}
@@ -0,0 +1,102 @@
+package noaa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seriesValues(names []string, values [][]float64, name string) []float64 {
+	for i, nm := range names {
+		if nm == name {
+			return values[i]
+		}
+	}
+	return nil
+}
+
+func TestForecastRegularStraddle(t *testing.T) {
+	var grid ForecastGridResponse
+	check(json.Unmarshal([]byte(`{
+		"validTimes": "2019-10-27T16:00:00+00:00/PT8H",
+		"elevation": {"value": 100, "unitCode": "unit:m"},
+		"quantitativePrecipitation": {"uom": "unit:mm", "values": [
+			{"validTime": "2019-10-27T17:00:00+00:00/PT2H", "value": 4},
+			{"validTime": "2019-10-27T19:00:00+00:00/PT4H", "value": 2}
+		]},
+		"skyCover": {"uom": "unit:percent", "values": [
+			{"validTime": "2019-10-27T16:00:00+00:00/PT4H", "value": 10},
+			{"validTime": "2019-10-27T20:00:00+00:00/PT4H", "value": 40}
+		]}
+	}`), &grid))
+
+	regular, err := CreateForecastRegular(&grid, 3*time.Hour)
+	check(err)
+	assert.Equal(t, 3*time.Hour, regular.Step)
+	assert.Equal(t, []time.Time{
+		time.Date(2019, 10, 27, 15, 0, 0, 0, time.UTC),
+		time.Date(2019, 10, 27, 18, 0, 0, 0, time.UTC),
+		time.Date(2019, 10, 27, 21, 0, 0, 0, time.UTC),
+	}, regular.Times)
+	// 17:00-19:00 and 19:00-23:00 are both split evenly between two steps
+	assert.Equal(t, []float64{2, 3, 1}, seriesValues(regular.SeriesNames, regular.Values, "PrecipitationQuantity"))
+	// 15:00-18:00 only has data from 16:00, 18:00-21:00 is weighted 2:1
+	assert.Equal(t, []float64{10, 20, 40}, seriesValues(regular.SeriesNames, regular.Values, "SkyCover"))
+
+	quarters, err := CreateForecastRegular(&grid, 15*time.Minute)
+	check(err)
+	assert.Equal(t, 32, len(quarters.Times))
+	precip := seriesValues(quarters.SeriesNames, quarters.Values, "PrecipitationQuantity")
+	assert.Equal(t, 0.5, precip[4])
+	// totals are not rounded so they add up to the grid
+	assert.Equal(t, 0.125, precip[12])
+
+	_, err = CreateForecastRegular(&grid, 0)
+	assert.Error(t, err)
+}
+
+func TestForecastRegularLinearMean(t *testing.T) {
+	var grid ForecastGridResponse
+	check(json.Unmarshal([]byte(`{
+		"validTimes": "2019-10-27T00:00:00+00:00/P1D",
+		"elevation": {"value": 100, "unitCode": "unit:m"},
+		"temperature": {"uom": "unit:degC", "values": [
+			{"validTime": "2019-10-27T00:00:00+00:00/PT12H", "value": 0},
+			{"validTime": "2019-10-27T12:00:00+00:00/PT12H", "value": 20}
+		]}
+	}`), &grid))
+
+	daily, err := CreateForecastRegular(&grid, 24*time.Hour)
+	check(err)
+	assert.Equal(t, []float64{10}, seriesValues(daily.SeriesNames, daily.Values, "Temperature"))
+
+	// shorter steps still interpolate between the interval starts
+	sixHours, err := CreateForecastRegular(&grid, 6*time.Hour)
+	check(err)
+	assert.Equal(t, []float64{0, 10, 20, 20}, seriesValues(sixHours.SeriesNames, sixHours.Values, "Temperature"))
+}
+
+func TestForecastRegularDaily(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	daily, err := CreateForecastRegular(fcst, 24*time.Hour)
+	check(err)
+	assert.Equal(t, 9, len(daily.Times))
+	assert.Equal(t, time.Date(2019, 10, 27, 0, 0, 0, 0, time.UTC), daily.Times[0])
+
+	total := 0.0
+	for _, v := range fcst.SnowFallAmount.Values {
+		total += v.Value
+	}
+	dailyTotal := 0.0
+	for _, v := range seriesValues(daily.SeriesNames, daily.Values, "SnowFallAmount") {
+		dailyTotal += v
+	}
+	assert.InDelta(t, total, dailyTotal, 1e-9)
+
+	hourly, err := CreateForecastHourly(fcst)
+	check(err)
+	assert.Equal(t, int(fcst.ValidTimes.Duration.Hours())+1, len(hourly.Times))
+}
@@ -0,0 +1,232 @@
+package noaa
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ResampleMethod selects how interval values are turned into a regular series
+type ResampleMethod int
+
+// Resampling methods
+const (
+	// ResampleStep repeats the value of each interval, steps covering several
+	// intervals take their time weighted mean, a circular mean for angle units.
+	// Gaps take the previous value and the edges are padded with the first and last values.
+	ResampleStep ResampleMethod = iota
+	// ResampleLinear interpolates linearly between the interval start times,
+	// suited to instantaneous quantities like temperature. Angles turn the short way.
+	// Steps longer than the intervals they cover take the time weighted mean instead.
+	ResampleLinear
+	// ResampleDistribute splits each interval total across the steps it covers
+	// in proportion to the overlap, suited to quantities like precipitation.
+	// Gaps and edges contribute nothing.
+	ResampleDistribute
+	// ResampleNaNFill is ResampleStep without fabricated data: gaps and edges are NaN
+	ResampleNaNFill
+)
+
+func (m ResampleMethod) String() string {
+	switch m {
+	case ResampleStep:
+		return "step"
+	case ResampleLinear:
+		return "linear"
+	case ResampleDistribute:
+		return "distribute"
+	case ResampleNaNFill:
+		return "nan-fill"
+	}
+	return fmt.Sprintf("ResampleMethod(%d)", int(m))
+}
+
+// DefaultResampleMethod is the method CreateForecastHourly uses for a series name
+func DefaultResampleMethod(name string) ResampleMethod {
+	switch name {
+	case "Temperature", "Dewpoint", "ApparentTemperature", "HeatIndex", "WindChill", "RelativeHumidity", "Pressure":
+		return ResampleLinear
+	case "PrecipitationQuantity", "SnowFallAmount", "IceAccumulation":
+		return ResampleDistribute
+	}
+	return ResampleStep
+}
+
+// Resample converts the timeseries to a regular series with the given step,
+// starting at the first interval truncated to step and covering every interval
+func (ts *ForecastTimeseries) Resample(step time.Duration, method ResampleMethod) (*ForecastTimeseries, error) {
+	if len(ts.Values) == 0 {
+		return nil, fmt.Errorf("no values to resample for %s at %s", ts.Name, ts.ID)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("invalid resampling step %s", step)
+	}
+	tMin := ts.Tmin().Truncate(step)
+	// the last step starts before the end of the last interval
+	tMax := tMin.Add((ts.Tmax().Sub(tMin) - 1) / step * step)
+	return ts.resample(tMin, tMax, step, method)
+}
+
+// resample returns the values at tMin, tMin+step, ... up to and including tMax
+func (ts *ForecastTimeseries) resample(tMin, tMax time.Time, step time.Duration, method ResampleMethod) (*ForecastTimeseries, error) {
+	if len(ts.Values) == 0 {
+		return nil, fmt.Errorf("no values to resample for %s at %s", ts.Name, ts.ID)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("invalid resampling step %s", step)
+	}
+	if tMax.Before(tMin) {
+		return nil, fmt.Errorf(
+			"resampling range of %s at %s ends before it starts: %s < %s",
+			ts.Name, ts.ID, tMax.Format(timeFormat), tMin.Format(timeFormat))
+	}
+	N := int(tMax.Sub(tMin)/step) + 1
+	out := make([]*ForecastTimeseriesValue, N)
+	for k := range out {
+		t := tMin.Add(time.Duration(k) * step)
+		var value float64
+		switch method {
+		case ResampleLinear:
+			var ok bool
+			if ts.spans(t, t.Add(step)) {
+				value, ok = ts.mean(t, t.Add(step))
+			}
+			if !ok {
+				value = ts.interpolate(t)
+			}
+		case ResampleDistribute:
+			value = ts.distribute(t, t.Add(step))
+		case ResampleStep, ResampleNaNFill:
+			var ok bool
+			value, ok = ts.mean(t, t.Add(step))
+			if !ok && method == ResampleNaNFill {
+				value = math.NaN()
+			} else if !ok {
+				value = ts.previous(t)
+			}
+		default:
+			return nil, fmt.Errorf("unknown resampling method %s", method)
+		}
+		out[k] = &ForecastTimeseriesValue{
+			Time:  ForecastTime{Time: t, Duration: step},
+			Value: value,
+		}
+	}
+	return &ForecastTimeseries{
+		Name:   ts.Name,
+		ID:     ts.ID,
+		Values: out,
+		Units:  ts.Units,
+	}, nil
+}
+
+// overlap is the duration the interval shares with [start, end)
+func overlap(ft ForecastTime, start, end time.Time) time.Duration {
+	from, to := ft.Time, ft.endTime()
+	if start.After(from) {
+		from = start
+	}
+	if end.Before(to) {
+		to = end
+	}
+	if !to.After(from) {
+		return 0
+	}
+	return to.Sub(from)
+}
+
+// mean is the time weighted mean of the values overlapping [start, end),
+// false when none do. Angles take the circular mean so 350 and 10 degrees give 0.
+func (ts *ForecastTimeseries) mean(start, end time.Time) (float64, bool) {
+	angle := isAngle(ts.Units)
+	var sum, sin, cos, weights float64
+	count := 0
+	last := 0.0
+	for _, v := range ts.Values {
+		o := overlap(v.Time, start, end)
+		if o == 0 {
+			continue
+		}
+		count++
+		last = v.Value
+		if angle {
+			sin += math.Sin(v.Value*math.Pi/180) * o.Seconds()
+			cos += math.Cos(v.Value*math.Pi/180) * o.Seconds()
+			continue
+		}
+		sum += v.Value * o.Seconds()
+		weights += o.Seconds()
+	}
+	switch {
+	case count == 0:
+		return 0, false
+	case count == 1:
+		// avoid rounding errors when a single interval covers the step
+		return last, true
+	case angle:
+		return circularMean(sin, cos), true
+	}
+	return sum / weights, true
+}
+
+// spans is true when [start, end) is longer than an interval it overlaps,
+// so sampling at start would miss the values of the others
+func (ts *ForecastTimeseries) spans(start, end time.Time) bool {
+	for _, v := range ts.Values {
+		if overlap(v.Time, start, end) > 0 && v.Time.Duration < end.Sub(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// distribute is the share of the interval totals falling in [start, end)
+func (ts *ForecastTimeseries) distribute(start, end time.Time) float64 {
+	total := 0.0
+	for _, v := range ts.Values {
+		o := overlap(v.Time, start, end)
+		if o == 0 {
+			continue
+		}
+		if o == v.Time.Duration {
+			total += v.Value
+		} else {
+			total += v.Value * o.Seconds() / v.Time.Duration.Seconds()
+		}
+	}
+	return total
+}
+
+// previous is the value of the last interval starting before t,
+// or the first value when t is before the series
+func (ts *ForecastTimeseries) previous(t time.Time) float64 {
+	value := ts.Values[0].Value
+	for _, v := range ts.Values {
+		if v.Time.Time.After(t) {
+			break
+		}
+		value = v.Value
+	}
+	return value
+}
+
+// interpolate is the value at t on the line through the interval start times
+func (ts *ForecastTimeseries) interpolate(t time.Time) float64 {
+	first := ts.Values[0]
+	if !t.After(first.Time.Time) {
+		return first.Value
+	}
+	for i := 1; i < len(ts.Values); i++ {
+		prev, next := ts.Values[i-1], ts.Values[i]
+		if next.Time.Time.After(t) {
+			frac := t.Sub(prev.Time.Time).Seconds() / next.Time.Time.Sub(prev.Time.Time).Seconds()
+			if isAngle(ts.Units) {
+				// turn by at most 180 degrees, e.g. from 350 through 0 to 10
+				delta := math.Mod(next.Value-prev.Value+540, 360) - 180
+				return math.Mod(prev.Value+frac*delta+360, 360)
+			}
+			return prev.Value + frac*(next.Value-prev.Value)
+		}
+	}
+	return ts.Values[len(ts.Values)-1].Value
+}
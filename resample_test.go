@@ -0,0 +1,126 @@
+package noaa
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/adamgreenhall/noaa/units"
+	"github.com/stretchr/testify/assert"
+)
+
+var resampleStart = time.Date(2020, 8, 19, 0, 0, 0, 0, time.UTC)
+
+// testSeries has a two hour interval, a one hour interval, a two hour gap and a three hour interval
+func testSeries() *ForecastTimeseries {
+	value := func(startHour, hours int, v float64) *ForecastTimeseriesValue {
+		return &ForecastTimeseriesValue{
+			Time:  ForecastTime{resampleStart.Add(time.Duration(startHour) * time.Hour), time.Duration(hours) * time.Hour},
+			Value: v,
+		}
+	}
+	return &ForecastTimeseries{
+		Name:   "test",
+		Values: []*ForecastTimeseriesValue{value(0, 2, 2), value(2, 1, 5), value(5, 3, 3)},
+	}
+}
+
+func resampledValues(t *testing.T, ts *ForecastTimeseries) []float64 {
+	out := make([]float64, len(ts.Values))
+	for i, v := range ts.Values {
+		out[i] = math.Round(v.Value*1000) / 1000
+	}
+	return out
+}
+
+func TestResampleMethods(t *testing.T) {
+	ts := testSeries()
+	tMax := resampleStart.Add(8 * time.Hour)
+	expected := map[ResampleMethod][]float64{
+		ResampleStep:       {2, 2, 5, 5, 5, 3, 3, 3, 3},
+		ResampleLinear:     {2, 3.5, 5, 4.333, 3.667, 3, 3, 3, 3},
+		ResampleDistribute: {1, 1, 5, 0, 0, 1, 1, 1, 0},
+	}
+	for method, values := range expected {
+		hourly, err := ts.resample(resampleStart, tMax, time.Hour, method)
+		check(err)
+		assert.Equal(t, values, resampledValues(t, hourly), method.String())
+		assert.Equal(t, resampleStart.Add(time.Hour), hourly.Values[1].Time.Time)
+		assert.Equal(t, time.Hour, hourly.Values[1].Time.Duration)
+	}
+
+	nan, err := ts.resample(resampleStart, tMax, time.Hour, ResampleNaNFill)
+	check(err)
+	for i, v := range nan.Values {
+		assert.Equal(t, i == 3 || i == 4 || i == 8, math.IsNaN(v.Value), i)
+	}
+}
+
+func TestResampleStep(t *testing.T) {
+	ts := testSeries()
+	coarse, err := ts.Resample(3*time.Hour, ResampleStep)
+	check(err)
+	assert.Equal(t, []float64{3, 3, 3}, resampledValues(t, coarse))
+
+	coarse, err = ts.Resample(3*time.Hour, ResampleDistribute)
+	check(err)
+	assert.Equal(t, []float64{7, 1, 2}, resampledValues(t, coarse))
+
+	_, err = ts.Resample(0, ResampleStep)
+	assert.Error(t, err)
+	_, err = (&ForecastTimeseries{}).Resample(time.Hour, ResampleStep)
+	assert.Error(t, err)
+}
+
+func TestResampleAngle(t *testing.T) {
+	value := func(startHour int, v float64) *ForecastTimeseriesValue {
+		return &ForecastTimeseriesValue{
+			Time:  ForecastTime{resampleStart.Add(time.Duration(startHour) * time.Hour), 2 * time.Hour},
+			Value: v,
+		}
+	}
+	ts := &ForecastTimeseries{
+		Name:   "WindDirection",
+		Units:  "wmoUnit:degree_(angle)",
+		Values: []*ForecastTimeseriesValue{value(0, 350), value(2, 10), value(4, 90)},
+	}
+	coarse, err := ts.Resample(4*time.Hour, ResampleStep)
+	check(err)
+	assert.Equal(t, []float64{0, 90}, resampledValues(t, coarse))
+
+	linear, err := ts.Resample(time.Hour, ResampleLinear)
+	check(err)
+	assert.Equal(t, []float64{350, 0, 10, 50, 90, 90}, resampledValues(t, linear))
+}
+
+func TestCreateForecastHourlyMethods(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	hourly, err := CreateForecastHourly(fcst)
+	check(err)
+
+	total := 0.0
+	for _, v := range fcst.PrecipitationQuantity.Values {
+		total += v.Value
+	}
+	for i, name := range hourly.SeriesNames {
+		if name != "PrecipitationQuantity" {
+			continue
+		}
+		hourlyTotal := 0.0
+		for _, v := range hourly.Values[i] {
+			hourlyTotal += v
+		}
+		assert.InDelta(t, total, hourlyTotal, 1e-9)
+
+		imperial, err := hourly.ToSystem(units.Imperial)
+		check(err)
+		imperialTotal := 0.0
+		for _, v := range imperial.Values[i] {
+			imperialTotal += v
+		}
+		assert.InDelta(t, total/25.4, imperialTotal, 1e-9)
+	}
+	// temperature is interpolated between 19:00 and 21:00
+	assert.Equal(t, math.Round((fcst.Temperature.Values[3].Value+fcst.Temperature.Values[4].Value)*5)/10, hourly.Values[0][4])
+}
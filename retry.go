@@ -0,0 +1,127 @@
+package noaa
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries transient failures: network errors and
+// timeouts, 429 Too Many Requests and 500/502/503/504 responses. Invalid URLs and
+// TLS certificate failures are not retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries including the first, 1 or less disables retries
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, doubled on every further attempt
+	BaseDelay time.Duration
+	// MaxDelay caps a single wait, including one requested by a Retry-After header
+	MaxDelay time.Duration
+	// Jitter is the fraction (0 to 1) of each wait that is randomized
+	Jitter float64
+}
+
+// DefaultRetryPolicy is used by NewClient
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.5,
+}
+
+// RetryError is returned when a request still failed after being retried
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s (after %d attempts)", e.Err.Error(), e.Attempts)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// delay is the wait before retrying after the given attempt (counting from 1)
+func (p *RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(p.Jitter * rand.Float64() * float64(delay))
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// retryable reports whether a response (or transport error) is worth retrying
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return retryableError(err)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryableError is true for network errors and timeouts, false for permanent
+// failures like invalid URLs or certificates the client does not trust
+func retryableError(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	// *url.Error is itself a net.Error, look at what it wraps
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if urlErr.Timeout() {
+			return true
+		}
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryAfter parses the Retry-After header, given in seconds or as an HTTP date
+func retryAfter(res *http.Response) time.Duration {
+	if res == nil {
+		return 0
+	}
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
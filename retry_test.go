@@ -0,0 +1,99 @@
+package noaa
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFlakyServer fails the first `failures` requests with the given status
+func newFlakyServer(failures int, status int, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if *calls <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"cwa": "SEW"}`))
+	}))
+}
+
+func newRetryClient(server *httptest.Server, attempts int) *Client {
+	client := newTestClient(server)
+	client.Retry = &RetryPolicy{MaxAttempts: attempts, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	return client
+}
+
+func TestRetryTransient(t *testing.T) {
+	calls := 0
+	server := newFlakyServer(2, http.StatusServiceUnavailable, &calls)
+	defer server.Close()
+
+	point, err := newRetryClient(server, 3).Points("47.6", "-122.3")
+	check(err)
+	assert.Equal(t, "SEW", point.CWA)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	calls := 0
+	server := newFlakyServer(5, http.StatusTooManyRequests, &calls)
+	defer server.Close()
+
+	_, err := newRetryClient(server, 3).Points("47.6", "-122.3")
+	var retryErr *RetryError
+	assert.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, 3, retryErr.Attempts)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryNotFound(t *testing.T) {
+	calls := 0
+	server := newFlakyServer(5, http.StatusNotFound, &calls)
+	defer server.Close()
+
+	_, err := newRetryClient(server, 3).Points("47.6", "-122.3")
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.delay(1, 0))
+	assert.Equal(t, 4*time.Second, policy.delay(3, 0))
+	assert.Equal(t, 5*time.Second, policy.delay(10, 0))
+	assert.Equal(t, 3*time.Second, policy.delay(1, 3*time.Second))
+	assert.Equal(t, 5*time.Second, policy.delay(1, time.Minute))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		delay := policy.delay(2, 0)
+		assert.True(t, delay > time.Second && delay <= 2*time.Second)
+	}
+}
+
+func TestRetryTransportErrors(t *testing.T) {
+	var retryErr *RetryError
+	// the TLS certificate of the server is not trusted by the default client
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	client := newRetryClient(server, 3)
+	client.HTTPClient = http.DefaultClient
+	_, err := client.GetEndpointGridForecast(server.URL + "/gridpoints/SEW/151,119")
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &retryErr))
+
+	_, err = client.GetEndpointGridForecast("http://\x00")
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &retryErr))
+
+	// connections to a closed server are refused and retried
+	server.Close()
+	_, err = newRetryClient(server, 3).GetEndpointGridForecast(server.URL + "/gridpoints/SEW/151,119")
+	assert.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, 3, retryErr.Attempts)
+}
@@ -0,0 +1,88 @@
+package noaa
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+)
+
+// Station holds the metadata of an observation station from /points/<lat,lon>/stations
+type Station struct {
+	ID              string            `json:"@id"`
+	Identifier      string            `json:"stationIdentifier"`
+	Name            string            `json:"name"`
+	Geometry        string            `json:"geometry"`
+	Elevation       QuantitativeValue `json:"elevation"`
+	Timezone        string            `json:"timeZone"`
+	ForecastZone    string            `json:"forecast"`
+	County          string            `json:"county"`
+	FireWeatherZone string            `json:"fireWeatherZone"`
+
+	// Location is parsed from Geometry
+	Location LatLon `json:"-"`
+	// Distance from the queried point in meters, +Inf when the station has no location
+	Distance float64 `json:"-"`
+	// Bearing from the queried point in degrees clockwise from north
+	Bearing float64 `json:"-"`
+}
+
+// UnmarshalJSON decodes the station and parses its location,
+// Location is left zero when the geometry is missing or cannot be parsed
+func (s *Station) UnmarshalJSON(buf []byte) error {
+	type station Station
+	if err := json.Unmarshal(buf, (*station)(s)); err != nil {
+		return err
+	}
+	s.Distance = math.Inf(1)
+	s.Location, _ = parseWKTPoint(s.Geometry)
+	return nil
+}
+
+// hasLocation is false for stations without a valid geometry
+func (s *Station) hasLocation() bool {
+	_, err := parseWKTPoint(s.Geometry)
+	return err == nil
+}
+
+// sortByDistance sets the distance and bearing of each station from point and sorts them nearest first
+func sortByDistance(stations []*Station, point LatLon) {
+	for _, s := range stations {
+		if !s.hasLocation() {
+			continue
+		}
+		s.Distance = point.DistanceTo(s.Location)
+		s.Bearing = point.BearingTo(s.Location)
+	}
+	sort.SliceStable(stations, func(i, j int) bool {
+		return stations[i].Distance < stations[j].Distance
+	})
+}
+
+// sortByDistance sorts Features nearest first from point and Stations in the same order,
+// stations without metadata go last
+func (r *StationsResponse) sortByDistance(point LatLon) {
+	sortByDistance(r.Features, point)
+	rank := make(map[string]int, len(r.Features))
+	for i, s := range r.Features {
+		rank[s.ID] = i
+	}
+	sort.SliceStable(r.Stations, func(i, j int) bool {
+		ri, ok := rank[r.Stations[i]]
+		if !ok {
+			ri = len(r.Features)
+		}
+		rj, ok := rank[r.Stations[j]]
+		if !ok {
+			rj = len(r.Features)
+		}
+		return ri < rj
+	})
+}
+
+// Nearest returns the station closest to the queried point, nil when there are none
+func (r *StationsResponse) Nearest() *Station {
+	if len(r.Features) == 0 {
+		return nil
+	}
+	return r.Features[0]
+}
@@ -0,0 +1,41 @@
+package noaa
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStationsNearest(t *testing.T) {
+	server := newTestServer(map[string]int{})
+	defer server.Close()
+	client := newTestClient(server)
+
+	stations, err := client.Stations("47.6", "-122.3")
+	check(err)
+	assert.Equal(t, 4, len(stations.Stations))
+	identifiers := []string{}
+	for _, s := range stations.Features {
+		identifiers = append(identifiers, s.Identifier)
+	}
+	// the station with a bad geometry has no distance and goes last
+	assert.Equal(t, []string{"KBFI", "KRNT", "KSEA", "KXXX"}, identifiers)
+	assert.True(t, math.IsInf(stations.Features[3].Distance, 1))
+	assert.Equal(t, LatLon{}, stations.Features[3].Location)
+	for i, s := range stations.Features {
+		assert.Equal(t, s.ID, stations.Stations[i])
+	}
+
+	nearest := stations.Nearest()
+	assert.Equal(t, "Seattle, Boeing Field", nearest.Name)
+	assert.Equal(t, LatLon{Lat: 47.53789, Lon: -122.31442}, nearest.Location)
+	assert.Equal(t, 6.096, *nearest.Elevation.Value)
+	assert.Equal(t, "America/Los_Angeles", nearest.Timezone)
+	assert.Equal(t, server.URL+"/zones/county/WAC033", nearest.County)
+	assert.InDelta(t, 6990, nearest.Distance, 10)
+	assert.InDelta(t, 189, nearest.Bearing, 1)
+	assert.True(t, stations.Features[1].Distance < stations.Features[2].Distance)
+
+	assert.Nil(t, (&StationsResponse{}).Nearest())
+}
@@ -0,0 +1,93 @@
+package noaa
+
+import (
+	"fmt"
+	"time"
+)
+
+// loadLocation loads an IANA timezone name, the empty name is UTC
+func loadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: %s", name, err.Error())
+	}
+	return loc, nil
+}
+
+// localDay is the local midnight starting the day of t
+func localDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// LocalDay is the range of Times falling on one local calendar day,
+// Times[Start:End]. Days with a daylight saving change have 23 or 25 hours.
+type LocalDay struct {
+	Date  time.Time
+	Start int
+	End   int
+}
+
+// Hours is the number of times in the day
+func (d LocalDay) Hours() int {
+	return d.End - d.Start
+}
+
+// groupLocalDays splits sorted times into local calendar days
+func groupLocalDays(times []time.Time, loc *time.Location) []LocalDay {
+	days := make([]LocalDay, 0)
+	for i, t := range times {
+		day := localDay(t, loc)
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(day) {
+			days = append(days, LocalDay{Date: day, Start: i})
+		}
+		days[len(days)-1].End = i + 1
+	}
+	return days
+}
+
+// Location is the timezone of the forecast point, UTC when unknown
+func (f *ForecastGridResponse) Location() (*time.Location, error) {
+	return loadLocation(f.Timezone)
+}
+
+// Location is the timezone of the forecast point, UTC when unknown
+func (h *ForecastHourly) Location() (*time.Location, error) {
+	return loadLocation(h.Timezone)
+}
+
+// LocalTimes returns Times in the timezone of the forecast point
+func (h *ForecastHourly) LocalTimes() ([]time.Time, error) {
+	loc, err := h.Location()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]time.Time, len(h.Times))
+	for i, t := range h.Times {
+		out[i] = t.In(loc)
+	}
+	return out, nil
+}
+
+// LocalLabels formats the local times with layout, e.g. "Mon 3PM MST".
+// Include the zone in layout to tell apart the repeated hour when daylight saving time ends.
+func (h *ForecastHourly) LocalLabels(layout string) ([]string, error) {
+	times, err := h.LocalTimes()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(times))
+	for i, t := range times {
+		out[i] = t.Format(layout)
+	}
+	return out, nil
+}
+
+// LocalDays groups Times into the local calendar days of the forecast point
+func (h *ForecastHourly) LocalDays() ([]LocalDay, error) {
+	loc, err := h.Location()
+	if err != nil {
+		return nil, err
+	}
+	return groupLocalDays(h.Times, loc), nil
+}
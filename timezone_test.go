@@ -0,0 +1,54 @@
+package noaa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastHourlyLocalTimes(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	fcst.Timezone = "America/Los_Angeles"
+	hourly, err := CreateForecastHourly(fcst)
+	check(err)
+	assert.Equal(t, "America/Los_Angeles", hourly.Timezone)
+
+	local, err := hourly.LocalTimes()
+	check(err)
+	assert.Equal(t, 9, local[0].Hour())
+	assert.True(t, local[0].Equal(hourly.Times[0]))
+
+	// 1AM happens twice when daylight saving time ends
+	labels, err := hourly.LocalLabels("Jan 2 3PM MST")
+	check(err)
+	first := time.Date(2019, 11, 3, 8, 0, 0, 0, time.UTC)
+	for i, tm := range hourly.Times {
+		if tm.Equal(first) {
+			assert.Equal(t, []string{"Nov 3 1AM PDT", "Nov 3 1AM PST", "Nov 3 2AM PST"}, labels[i:i+3])
+		}
+	}
+
+	days, err := hourly.LocalDays()
+	check(err)
+	assert.Equal(t, 9, len(days))
+	assert.Equal(t, 15, days[0].Hours())
+	assert.Equal(t, 25, days[7].Hours())
+	assert.Equal(t, 3, days[7].Date.Day())
+	assert.Equal(t, days[7].End, days[8].Start)
+	assert.Equal(t, len(hourly.Times), days[8].End)
+
+	daily, err := CreateForecastDaily(fcst, "")
+	check(err)
+	assert.Equal(t, "America/Los_Angeles", daily.Timezone)
+
+	hourly.Timezone = ""
+	days, err = hourly.LocalDays()
+	check(err)
+	assert.Equal(t, 24, days[1].Hours())
+
+	hourly.Timezone = "Nowhere/Special"
+	_, err = hourly.LocalTimes()
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+// Package units parses the WMO / UCUM unit codes used by api.weather.gov
+// (e.g. "unit:degC", "wmoUnit:km_h-1") and converts values between them.
+package units
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dimension is the physical quantity a unit measures
+type Dimension int
+
+// Dimensions of the supported units
+const (
+	Dimensionless Dimension = iota
+	Temperature
+	Speed
+	Length
+	Angle
+	Pressure
+	Time
+	Ratio
+)
+
+// System selects the target units of a conversion
+type System int
+
+// Unit systems
+const (
+	Metric System = iota
+	Imperial
+)
+
+// Unit is a parsed unit code. Values are converted through the base unit of
+// the dimension: base = value*scale + offset
+type Unit struct {
+	// Code is the canonical code without prefix, e.g. "degC"
+	Code string
+	// Symbol is a short label, e.g. "C" or "m/s"
+	Symbol    string
+	Dimension Dimension
+	scale     float64
+	offset    float64
+	// metric and imperial are the codes of the counterparts in each system,
+	// empty when the unit already belongs to it
+	metric   string
+	imperial string
+}
+
+var known = []Unit{
+	{Code: "K", Symbol: "K", Dimension: Temperature, scale: 1, metric: "degC", imperial: "degF"},
+	{Code: "degC", Symbol: "C", Dimension: Temperature, scale: 1, offset: 273.15, imperial: "degF"},
+	{Code: "degF", Symbol: "F", Dimension: Temperature, scale: 5.0 / 9, offset: 273.15 - 32*5.0/9, metric: "degC"},
+	{Code: "m_s-1", Symbol: "m/s", Dimension: Speed, scale: 1, imperial: "mi_h-1"},
+	{Code: "km_h-1", Symbol: "km/h", Dimension: Speed, scale: 1000.0 / 3600, imperial: "mi_h-1"},
+	{Code: "mi_h-1", Symbol: "mph", Dimension: Speed, scale: 1609.344 / 3600, metric: "km_h-1"},
+	{Code: "kt", Symbol: "kt", Dimension: Speed, scale: 1852.0 / 3600, metric: "km_h-1", imperial: "mi_h-1"},
+	{Code: "m", Symbol: "m", Dimension: Length, scale: 1, imperial: "ft"},
+	{Code: "km", Symbol: "km", Dimension: Length, scale: 1000, imperial: "mi"},
+	{Code: "cm", Symbol: "cm", Dimension: Length, scale: 0.01, imperial: "in"},
+	{Code: "mm", Symbol: "mm", Dimension: Length, scale: 0.001, imperial: "in"},
+	{Code: "ft", Symbol: "ft", Dimension: Length, scale: 0.3048, metric: "m"},
+	{Code: "in", Symbol: "in", Dimension: Length, scale: 0.0254, metric: "mm"},
+	{Code: "mi", Symbol: "mi", Dimension: Length, scale: 1609.344, metric: "km"},
+	{Code: "degree_(angle)", Symbol: "deg", Dimension: Angle, scale: 1},
+	{Code: "Pa", Symbol: "Pa", Dimension: Pressure, scale: 1, imperial: "inHg"},
+	{Code: "hPa", Symbol: "hPa", Dimension: Pressure, scale: 100, imperial: "inHg"},
+	{Code: "inHg", Symbol: "inHg", Dimension: Pressure, scale: 3386.389, metric: "hPa"},
+	{Code: "s", Symbol: "s", Dimension: Time, scale: 1},
+	{Code: "min", Symbol: "min", Dimension: Time, scale: 60},
+	{Code: "h", Symbol: "h", Dimension: Time, scale: 3600},
+	{Code: "percent", Symbol: "percent", Dimension: Ratio, scale: 1},
+	{Code: "1", Symbol: "", Dimension: Dimensionless, scale: 1},
+}
+
+// aliases maps other spellings (UCUM, symbols) to canonical codes
+var aliases = map[string]string{
+	"Cel":       "degC",
+	"C":         "degC",
+	"[degF]":    "degF",
+	"F":         "degF",
+	"m/s":       "m_s-1",
+	"km/h":      "km_h-1",
+	"[mi_i]/h":  "mi_h-1",
+	"mph":       "mi_h-1",
+	"[kn_i]":    "kt",
+	"kn":        "kt",
+	"[ft_i]":    "ft",
+	"[in_i]":    "in",
+	"[mi_i]":    "mi",
+	"deg":       "degree_(angle)",
+	"%":         "percent",
+	"[in_i'Hg]": "inHg",
+	"":          "1",
+}
+
+var byCode = func() map[string]Unit {
+	out := make(map[string]Unit, len(known))
+	for _, u := range known {
+		out[u.Code] = u
+	}
+	return out
+}()
+
+// Parse reads a unit code with or without its "unit:" / "wmoUnit:" prefix
+func Parse(code string) (Unit, error) {
+	trimmed := code
+	if i := strings.LastIndex(trimmed, ":"); i >= 0 {
+		trimmed = trimmed[i+1:]
+	}
+	if alias, ok := aliases[trimmed]; ok {
+		trimmed = alias
+	}
+	u, ok := byCode[trimmed]
+	if !ok {
+		return Unit{}, fmt.Errorf("unknown unit code %q", code)
+	}
+	return u, nil
+}
+
+// MustParse is Parse for codes known to be valid, it panics otherwise
+func MustParse(code string) Unit {
+	u, err := Parse(code)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// String is the API form of the code, e.g. "unit:degC"
+func (u Unit) String() string {
+	return "unit:" + u.Code
+}
+
+// In returns the unit of the same dimension used by system
+func (u Unit) In(system System) Unit {
+	target := u.metric
+	if system == Imperial {
+		target = u.imperial
+	}
+	if target == "" {
+		return u
+	}
+	return byCode[target]
+}
+
+// Convert converts value from one unit to another of the same dimension
+func Convert(value float64, from Unit, to Unit) (float64, error) {
+	if from.Dimension != to.Dimension {
+		return 0, fmt.Errorf("cannot convert %s to %s", from.Code, to.Code)
+	}
+	if from.Code == to.Code {
+		return value, nil
+	}
+	base := value*from.scale + from.offset
+	return (base - to.offset) / to.scale, nil
+}
+
+// ConvertCode is Convert with unparsed unit codes
+func ConvertCode(value float64, from string, to string) (float64, error) {
+	fromUnit, err := Parse(from)
+	if err != nil {
+		return 0, err
+	}
+	toUnit, err := Parse(to)
+	if err != nil {
+		return 0, err
+	}
+	return Convert(value, fromUnit, toUnit)
+}
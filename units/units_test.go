@@ -0,0 +1,61 @@
+package units
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for code, expected := range map[string]string{
+		"unit:degC":           "degC",
+		"wmoUnit:degC":        "degC",
+		"unit:km_h-1":         "km_h-1",
+		"unit:degree_(angle)": "degree_(angle)",
+		"[degF]":              "degF",
+		"m/s":                 "m_s-1",
+		"%":                   "percent",
+		"":                    "1",
+	} {
+		u, err := Parse(code)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, u.Code)
+	}
+	_, err := Parse("unit:furlong")
+	assert.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	testCases := []struct {
+		value, expected float64
+		from, to        string
+	}{
+		{0, 32, "unit:degC", "unit:degF"},
+		{-40, -40, "unit:degF", "unit:degC"},
+		{300, 26.85, "unit:K", "unit:degC"},
+		{10, 36, "unit:m_s-1", "unit:km_h-1"},
+		{100, 62.137, "unit:km_h-1", "unit:mi_h-1"},
+		{25.4, 1, "unit:mm", "unit:in"},
+		{1000, 3280.84, "unit:m", "unit:ft"},
+		{1013.25, 29.921, "unit:hPa", "unit:inHg"},
+	}
+	for _, tc := range testCases {
+		value, err := ConvertCode(tc.value, tc.from, tc.to)
+		assert.Nil(t, err)
+		assert.True(t, math.Abs(value-tc.expected) < 1e-3, "%v %s = %v %s, got %v", tc.value, tc.from, tc.expected, tc.to, value)
+	}
+	_, err := ConvertCode(1, "unit:m", "unit:degC")
+	assert.Error(t, err)
+}
+
+func TestSystems(t *testing.T) {
+	assert.Equal(t, "degF", MustParse("unit:degC").In(Imperial).Code)
+	assert.Equal(t, "degC", MustParse("unit:degC").In(Metric).Code)
+	assert.Equal(t, "mi_h-1", MustParse("unit:m_s-1").In(Imperial).Code)
+	assert.Equal(t, "m_s-1", MustParse("unit:m_s-1").In(Metric).Code)
+	assert.Equal(t, "km_h-1", MustParse("unit:kt").In(Metric).Code)
+	assert.Equal(t, "mm", MustParse("unit:in").In(Metric).Code)
+	assert.Equal(t, "percent", MustParse("unit:percent").In(Imperial).Code)
+	assert.Equal(t, "unit:degF", MustParse("F").String())
+}
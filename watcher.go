@@ -0,0 +1,213 @@
+package noaa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is the polling interval of a Watcher
+const DefaultWatchInterval = 30 * time.Second
+
+// AlertEventType tells what happened to an alert
+type AlertEventType int
+
+// Alert event types
+const (
+	// AlertNew is an alert seen for the first time
+	AlertNew AlertEventType = iota
+	// AlertUpdated is an alert referencing an earlier one, see AlertEvent.Previous
+	AlertUpdated
+	// AlertCancelled is a cancel message, or a known alert referenced by one
+	AlertCancelled
+	// AlertExpired is a known alert that is no longer active
+	AlertExpired
+)
+
+func (t AlertEventType) String() string {
+	switch t {
+	case AlertNew:
+		return "new"
+	case AlertUpdated:
+		return "updated"
+	case AlertCancelled:
+		return "cancelled"
+	case AlertExpired:
+		return "expired"
+	}
+	return fmt.Sprintf("AlertEventType(%d)", int(t))
+}
+
+// AlertEvent is a change in the active alerts seen by a Watcher
+type AlertEvent struct {
+	Type  AlertEventType
+	Alert *Alert
+	// Previous is the known alert replaced by an update or cancel message
+	Previous *Alert
+}
+
+// Watcher polls /alerts/active for a set of queries and reports the changes.
+// Alerts are deduplicated by ID across the queries.
+type Watcher struct {
+	// Client makes the requests, nil uses DefaultClient
+	Client *Client
+	// Queries select the alerts to watch, e.g. by Point or Zones. Active is implied.
+	Queries []AlertsQuery
+	// Interval between polls, zero uses DefaultWatchInterval
+	Interval time.Duration
+	// OnError receives polling errors, nil logs them with the client Logger
+	OnError func(error)
+
+	mu    sync.Mutex
+	known map[string]*Alert
+	order []*Alert
+	cache *ConditionalCache
+}
+
+// NewWatcher returns a Watcher polling the active alerts matching queries
+func NewWatcher(client *Client, queries ...AlertsQuery) *Watcher {
+	return &Watcher{Client: client, Queries: queries}
+}
+
+func (w *Watcher) client() *Client {
+	if w.Client == nil {
+		return DefaultClient
+	}
+	return w.Client
+}
+
+func (w *Watcher) interval() time.Duration {
+	if w.Interval <= 0 {
+		return DefaultWatchInterval
+	}
+	return w.Interval
+}
+
+// Watch polls until ctx is done and sends the events on the returned channel,
+// which is closed when the watcher stops. The first poll reports every active alert as new,
+// including updates and cancel messages, as there is nothing known to compare them with.
+func (w *Watcher) Watch(ctx context.Context) <-chan AlertEvent {
+	events := make(chan AlertEvent)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(w.interval())
+		defer ticker.Stop()
+		for {
+			polled, err := w.Poll(ctx)
+			if err != nil && ctx.Err() == nil {
+				if w.OnError != nil {
+					w.OnError(err)
+				} else {
+					w.client().logf("polling alerts: %s", err)
+				}
+			}
+			for _, event := range polled {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// Poll fetches the active alerts once and returns the changes since the last poll.
+// On error no changes are recorded.
+func (w *Watcher) Poll(ctx context.Context) ([]AlertEvent, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// the first poll has nothing to compare with
+	first := w.known == nil
+	if first {
+		w.known = make(map[string]*Alert)
+		w.cache = NewConditionalCache(0)
+	}
+	current := make(map[string]*Alert)
+	order := make([]*Alert, 0)
+	for _, query := range w.Queries {
+		query.Active = true
+		alerts, err := w.fetch(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, alert := range alerts {
+			if _, ok := current[alert.ID]; !ok {
+				current[alert.ID] = alert
+				order = append(order, alert)
+			}
+		}
+	}
+
+	events := make([]AlertEvent, 0)
+	replaced := make(map[string]bool)
+	for _, alert := range order {
+		if _, ok := w.known[alert.ID]; ok {
+			continue
+		}
+		var previous *Alert
+		for _, ref := range alert.References {
+			if known, ok := w.known[ref.ID]; ok {
+				previous = known
+				replaced[ref.ID] = true
+			}
+		}
+		switch {
+		case first:
+			events = append(events, AlertEvent{Type: AlertNew, Alert: alert})
+		case alert.IsMessageType(AlertMessageCancel):
+			events = append(events, AlertEvent{Type: AlertCancelled, Alert: alert, Previous: previous})
+		case previous != nil || alert.IsMessageType(AlertMessageUpdate):
+			events = append(events, AlertEvent{Type: AlertUpdated, Alert: alert, Previous: previous})
+		default:
+			events = append(events, AlertEvent{Type: AlertNew, Alert: alert})
+		}
+	}
+	for _, alert := range w.order {
+		// a cancel message leaving the active alerts was already reported as cancelled
+		if alert.IsMessageType(AlertMessageCancel) {
+			continue
+		}
+		if _, ok := current[alert.ID]; !ok && !replaced[alert.ID] {
+			events = append(events, AlertEvent{Type: AlertExpired, Alert: alert})
+		}
+	}
+	w.known = current
+	w.order = order
+	return events, nil
+}
+
+// fetch returns the alerts of query, revalidating the previous response with If-Modified-Since
+func (w *Watcher) fetch(ctx context.Context, query AlertsQuery) ([]*Alert, error) {
+	c := w.client()
+	endpoint, err := query.endpoint(c.baseURL())
+	if err != nil {
+		return nil, err
+	}
+	cached, _ := w.cache.get(endpoint)
+	res, err := c.apiCallHeader(ctx, endpoint, cached.header())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotModified {
+		return cached.value.([]*Alert), nil
+	}
+	var page alertsResponse
+	decoder := json.NewDecoder(res.Body)
+	if err = decoder.Decode(&page); err != nil {
+		return nil, err
+	}
+	c.logParseErrors(page.Alerts)
+	w.cache.set(endpoint, res.Header, page.Alerts)
+	return page.Alerts, nil
+}
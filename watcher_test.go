@@ -0,0 +1,124 @@
+package noaa
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// alertStandIn serves /alerts/active from a mutable list with Last-Modified revalidation
+type alertStandIn struct {
+	mu           sync.Mutex
+	alerts       []string
+	modified     time.Time
+	notModified  int
+	lastEndpoint string
+}
+
+func (s *alertStandIn) setAlerts(alerts ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = alerts
+	s.modified = s.modified.Add(time.Minute)
+}
+
+func (s *alertStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastEndpoint = r.URL.String()
+	if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !s.modified.After(since) {
+		s.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Last-Modified", s.modified.UTC().Format(http.TimeFormat))
+	fmt.Fprintf(w, `{"@graph": [%s]}`, strings.Join(s.alerts, ","))
+}
+
+func watcherAlert(id string, messageType string, references ...string) string {
+	refs := make([]string, len(references))
+	for i, ref := range references {
+		refs[i] = fmt.Sprintf(`{"identifier": "%s"}`, ref)
+	}
+	return fmt.Sprintf(`{"id": "%s", "messageType": "%s", "event": "Flood Warning", "references": [%s]}`,
+		id, messageType, strings.Join(refs, ","))
+}
+
+func eventTypes(events []AlertEvent) []string {
+	out := make([]string, len(events))
+	for i, event := range events {
+		out[i] = event.Type.String() + " " + event.Alert.ID
+	}
+	return out
+}
+
+func TestWatcherPoll(t *testing.T) {
+	standIn := &alertStandIn{modified: time.Date(2019, 10, 27, 0, 0, 0, 0, time.UTC)}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+	watcher := NewWatcher(newTestClient(server), AlertsQuery{Zones: []string{"WAZ558"}}, AlertsQuery{Areas: []string{"WA"}})
+
+	standIn.setAlerts(watcherAlert("a", "Alert"), watcherAlert("b", "Alert"), watcherAlert("d2", "Update", "d"))
+	events, err := watcher.Poll(context.Background())
+	check(err)
+	// both queries return the same alerts, all new on the first poll
+	assert.Equal(t, []string{"new a", "new b", "new d2"}, eventTypes(events))
+	assert.Nil(t, events[2].Previous)
+	assert.Equal(t, "/alerts/active?area=WA", standIn.lastEndpoint)
+
+	// unchanged responses are revalidated
+	events, err = watcher.Poll(context.Background())
+	check(err)
+	assert.Empty(t, events)
+	assert.Equal(t, 2, standIn.notModified)
+
+	standIn.setAlerts(watcherAlert("a2", "Update", "a"), watcherAlert("b", "Alert"), watcherAlert("c", "Alert"))
+	events, err = watcher.Poll(context.Background())
+	check(err)
+	assert.Equal(t, []string{"updated a2", "new c", "expired d2"}, eventTypes(events))
+	assert.Equal(t, "a", events[0].Previous.ID)
+
+	standIn.setAlerts(watcherAlert("a2", "Alert"), watcherAlert("c3", "Cancel", "c"))
+	events, err = watcher.Poll(context.Background())
+	check(err)
+	assert.Equal(t, []string{"cancelled c3", "expired b"}, eventTypes(events))
+	assert.Equal(t, "c", events[0].Previous.ID)
+
+	// the cancel message leaving the active alerts is not reported again
+	standIn.setAlerts(watcherAlert("a2", "Alert"))
+	events, err = watcher.Poll(context.Background())
+	check(err)
+	assert.Empty(t, events)
+
+	_, err = NewWatcher(newTestClient(server), AlertsQuery{Zones: []string{"x"}, Areas: []string{"y"}}).Poll(context.Background())
+	assert.Error(t, err)
+}
+
+func TestWatcherWatch(t *testing.T) {
+	standIn := &alertStandIn{}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+	watcher := NewWatcher(newTestClient(server), AlertsQuery{Point: &LatLon{Lat: 47.6, Lon: -122.3}})
+	watcher.Interval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := watcher.Watch(ctx)
+	standIn.setAlerts(watcherAlert("a", "Alert"))
+	select {
+	case event := <-events:
+		assert.Equal(t, AlertNew, event.Type)
+		assert.Equal(t, "a", event.Alert.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no alert event")
+	}
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,167 @@
+package noaa
+
+import (
+	"strings"
+	"time"
+)
+
+// WeatherCoverage is how likely or widespread a weather condition is
+type WeatherCoverage string
+
+// Coverage values used by the gridpoint weather layer
+const (
+	CoverageAreas        WeatherCoverage = "areas"
+	CoverageBrief        WeatherCoverage = "brief"
+	CoverageChance       WeatherCoverage = "chance"
+	CoverageDefinite     WeatherCoverage = "definite"
+	CoverageFew          WeatherCoverage = "few"
+	CoverageFrequent     WeatherCoverage = "frequent"
+	CoverageIntermittent WeatherCoverage = "intermittent"
+	CoverageIsolated     WeatherCoverage = "isolated"
+	CoverageLikely       WeatherCoverage = "likely"
+	CoverageNumerous     WeatherCoverage = "numerous"
+	CoverageOccasional   WeatherCoverage = "occasional"
+	CoveragePatchy       WeatherCoverage = "patchy"
+	CoveragePeriods      WeatherCoverage = "periods"
+	CoverageScattered    WeatherCoverage = "scattered"
+	CoverageSlightChance WeatherCoverage = "slight_chance"
+	CoverageWidespread   WeatherCoverage = "widespread"
+)
+
+// WeatherType is the kind of weather expected
+type WeatherType string
+
+// Weather types used by the gridpoint weather layer
+const (
+	WeatherBlowingDust     WeatherType = "blowing_dust"
+	WeatherBlowingSand     WeatherType = "blowing_sand"
+	WeatherBlowingSnow     WeatherType = "blowing_snow"
+	WeatherDrizzle         WeatherType = "drizzle"
+	WeatherFog             WeatherType = "fog"
+	WeatherFreezingFog     WeatherType = "freezing_fog"
+	WeatherFreezingDrizzle WeatherType = "freezing_drizzle"
+	WeatherFreezingRain    WeatherType = "freezing_rain"
+	WeatherFreezingSpray   WeatherType = "freezing_spray"
+	WeatherFrost           WeatherType = "frost"
+	WeatherHail            WeatherType = "hail"
+	WeatherHaze            WeatherType = "haze"
+	WeatherIceCrystals     WeatherType = "ice_crystals"
+	WeatherIceFog          WeatherType = "ice_fog"
+	WeatherRain            WeatherType = "rain"
+	WeatherRainShowers     WeatherType = "rain_showers"
+	WeatherSleet           WeatherType = "sleet"
+	WeatherSmoke           WeatherType = "smoke"
+	WeatherSnow            WeatherType = "snow"
+	WeatherSnowShowers     WeatherType = "snow_showers"
+	WeatherThunderstorms   WeatherType = "thunderstorms"
+	WeatherVolcanicAsh     WeatherType = "volcanic_ash"
+	WeatherWaterSpouts     WeatherType = "water_spouts"
+)
+
+// WeatherIntensity is how strong a weather condition is
+type WeatherIntensity string
+
+// Intensity values used by the gridpoint weather layer
+const (
+	IntensityVeryLight WeatherIntensity = "very_light"
+	IntensityLight     WeatherIntensity = "light"
+	IntensityModerate  WeatherIntensity = "moderate"
+	IntensityHeavy     WeatherIntensity = "heavy"
+)
+
+// WeatherCondition is one entry of the gridpoint weather layer
+type WeatherCondition struct {
+	Coverage   WeatherCoverage   `json:"coverage"`
+	Weather    WeatherType       `json:"weather"`
+	Intensity  WeatherIntensity  `json:"intensity"`
+	Visibility QuantitativeValue `json:"visibility"`
+	Attributes []string          `json:"attributes"`
+}
+
+// WeatherTimeseries holds the weather layer from within ForecastGridResponse
+type WeatherTimeseries struct {
+	Values []*WeatherTimeseriesValue `json:"values"`
+}
+
+// WeatherTimeseriesValue holds the weather conditions of one time interval
+type WeatherTimeseriesValue struct {
+	Time  ForecastTime       `json:"validTime"`
+	Value []WeatherCondition `json:"value"`
+}
+
+func words(s string) string {
+	return strings.ReplaceAll(s, "_", " ")
+}
+
+// String describes the condition like the NWS text forecasts, e.g. "slight chance of light snow"
+func (c WeatherCondition) String() string {
+	if c.Weather == "" {
+		return ""
+	}
+	desc := words(string(c.Weather))
+	if c.Intensity != "" && c.Intensity != IntensityModerate {
+		desc = words(string(c.Intensity)) + " " + desc
+	}
+	switch c.Coverage {
+	case "", CoverageDefinite:
+	case CoverageSlightChance, CoverageChance:
+		desc = words(string(c.Coverage)) + " of " + desc
+	case CoverageLikely:
+		desc = desc + " likely"
+	case CoverageAreas, CoveragePeriods:
+		desc = string(c.Coverage) + " of " + desc
+	default:
+		desc = string(c.Coverage) + " " + desc
+	}
+	if len(c.Attributes) > 0 {
+		attributes := make([]string, len(c.Attributes))
+		for i, attr := range c.Attributes {
+			attributes[i] = words(attr)
+		}
+		desc += " with " + strings.Join(attributes, " and ")
+	}
+	return desc
+}
+
+// SummarizeWeather joins the descriptions of the conditions,
+// it is empty when no weather is expected
+func SummarizeWeather(conditions []WeatherCondition) string {
+	descs := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		if desc := c.String(); desc != "" {
+			descs = append(descs, desc)
+		}
+	}
+	return strings.Join(descs, " and ")
+}
+
+// At returns the conditions in effect at each of times, e.g. ForecastHourly.Times.
+// Intervals without weather give an empty slice.
+func (w *WeatherTimeseries) At(times []time.Time) [][]WeatherCondition {
+	intervals := make([]ForecastTime, len(w.Values))
+	for i, v := range w.Values {
+		intervals[i] = v.Time
+	}
+	out := make([][]WeatherCondition, len(times))
+	for i, idx := range intervalIndices(times, intervals) {
+		out[i] = []WeatherCondition{}
+		if idx < 0 {
+			continue
+		}
+		for _, c := range w.Values[idx].Value {
+			if c.Weather != "" {
+				out[i] = append(out[i], c)
+			}
+		}
+	}
+	return out
+}
+
+// WeatherSummary describes the weather of each hour, see SummarizeWeather
+func (h *ForecastHourly) WeatherSummary() []string {
+	out := make([]string, len(h.Weather))
+	for i, conditions := range h.Weather {
+		out[i] = SummarizeWeather(conditions)
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package noaa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeatherConditionString(t *testing.T) {
+	testCases := map[string]WeatherCondition{
+		"slight chance of light snow": {Coverage: CoverageSlightChance, Weather: WeatherSnow, Intensity: IntensityLight},
+		"rain showers likely":         {Coverage: CoverageLikely, Weather: WeatherRainShowers, Intensity: IntensityModerate},
+		"areas of fog":                {Coverage: CoverageAreas, Weather: WeatherFog},
+		"scattered thunderstorms with gusty wind and small hail": {
+			Coverage:   CoverageScattered,
+			Weather:    WeatherThunderstorms,
+			Attributes: []string{"gusty_wind", "small_hail"},
+		},
+		"": {},
+	}
+	for expected, c := range testCases {
+		assert.Equal(t, expected, c.String())
+	}
+	assert.Equal(t, "patchy fog and chance of very light drizzle", SummarizeWeather([]WeatherCondition{
+		{Coverage: CoveragePatchy, Weather: WeatherFog},
+		{},
+		{Coverage: CoverageChance, Weather: WeatherDrizzle, Intensity: IntensityVeryLight},
+	}))
+}
+
+func TestWeatherHourly(t *testing.T) {
+	fcst, err := readForecast("test_cases/gridForecast1.json")
+	check(err)
+	assert.Equal(t, 3, len(fcst.Weather.Values))
+
+	times := []time.Time{
+		time.Date(2019, 10, 27, 16, 0, 0, 0, time.UTC),
+		time.Date(2019, 11, 3, 6, 0, 0, 0, time.UTC),
+		time.Date(2019, 11, 3, 18, 0, 0, 0, time.UTC),
+		time.Date(2019, 11, 5, 0, 0, 0, 0, time.UTC),
+	}
+	weather := fcst.Weather.At(times)
+	assert.Equal(t, 0, len(weather[0]))
+	assert.Equal(t, CoverageSlightChance, weather[1][0].Coverage)
+	assert.Equal(t, CoverageChance, weather[2][0].Coverage)
+	assert.Equal(t, 0, len(weather[3]))
+
+	hourly, err := CreateForecastHourly(fcst)
+	check(err)
+	assert.Equal(t, len(hourly.Times), len(hourly.Weather))
+	summary := hourly.WeatherSummary()
+	assert.Equal(t, "", summary[0])
+	assert.Contains(t, summary, "slight chance of light snow")
+	assert.Contains(t, summary, "chance of light snow")
+}